@@ -0,0 +1,43 @@
+package async
+
+import "time"
+
+// OverflowPolicy defines what an AsyncWriter does with a write when its buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowWriteThrough writes the data directly to the target writer from the
+	// calling goroutine. The data may be written before the pending writes.
+	OverflowWriteThrough OverflowPolicy = iota
+	// OverflowBlock waits until there is room in the buffer.
+	OverflowBlock
+	// OverflowDropNewest discards the data being written.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest pending write to make room for the data.
+	OverflowDropOldest
+	// OverflowSpill stores the data in a temporary file until the background
+	// goroutine catches up, keeping the order of the writes.
+	OverflowSpill
+)
+
+// Options configures an AsyncWriter.
+type Options struct {
+	// BufferSize is the number of pending writes that can be queued in memory.
+	// Defaults to 100.
+	BufferSize int
+	// Overflow is the policy applied when the buffer is full.
+	// Defaults to OverflowWriteThrough.
+	Overflow OverflowPolicy
+	// SpillDir is the directory of the temporary files used by OverflowSpill.
+	// Defaults to os.TempDir().
+	SpillDir string
+	// MaxBatchBytes is the maximum size of the buffer in which pending writes are
+	// coalesced into a single write to the target, such as 64 KiB for files.
+	// Defaults to zero, which writes every write separately, as needed by
+	// message-oriented targets such as datagram sockets.
+	MaxBatchBytes int
+	// FlushInterval is how long the background goroutine waits for more writes
+	// before writing a batch smaller than MaxBatchBytes. Defaults to zero, which
+	// writes whatever is pending as soon as possible.
+	FlushInterval time.Duration
+}
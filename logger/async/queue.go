@@ -0,0 +1,62 @@
+package async
+
+// maxRetainedQueueBytes is the capacity above which the queue buffer is released after use.
+const maxRetainedQueueBytes = 1024 * 1024
+
+// queue stores the pending writes one after another in a single buffer, so
+// queuing does not allocate once the buffer has grown.
+type queue struct {
+	data []byte
+	ends []int // End offset of each write in data
+	head int   // Index of the first write that was not dropped
+}
+
+// len returns the number of writes in the queue.
+func (q *queue) len() int {
+	return len(q.ends) - q.head
+}
+
+// size returns the number of bytes in the queue.
+func (q *queue) size() int {
+	if q.len() == 0 {
+		return 0
+	}
+	return len(q.data) - q.start(q.head)
+}
+
+// start returns the start offset of the i-th write.
+func (q *queue) start(i int) int {
+	if i == 0 {
+		return 0
+	}
+	return q.ends[i-1]
+}
+
+// push appends a copy of the data to the queue.
+func (q *queue) push(p []byte) {
+	q.data = append(q.data, p...)
+	q.ends = append(q.ends, len(q.data))
+}
+
+// dropFront removes the oldest write from the queue.
+func (q *queue) dropFront() {
+	q.head++
+}
+
+// each calls fn with every write in the queue, in order, until fn returns false.
+func (q *queue) each(fn func(data []byte) bool) {
+	for i := q.head; i < len(q.ends); i++ {
+		if !fn(q.data[q.start(i):q.ends[i]]) {
+			return
+		}
+	}
+}
+
+// reset empties the queue, keeping its buffers unless they grew too much.
+func (q *queue) reset() {
+	if cap(q.data) > maxRetainedQueueBytes {
+		*q = queue{}
+		return
+	}
+	q.data, q.ends, q.head = q.data[:0], q.ends[:0], 0
+}
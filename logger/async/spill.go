@@ -0,0 +1,76 @@
+package async
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+)
+
+// spillFile stores writes in a temporary file, each one prefixed by its length.
+type spillFile struct {
+	file  *os.File
+	w     *bufio.Writer
+	count int // Number of writes stored
+}
+
+// newSpillFile creates an empty spill file in the given directory.
+func newSpillFile(dir string) (*spillFile, error) {
+	file, err := os.CreateTemp(dir, "async-spill-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &spillFile{file: file, w: bufio.NewWriter(file)}, nil
+}
+
+// append stores the data at the end of the file.
+func (s *spillFile) append(data []byte) error {
+	var size [4]byte
+	binary.BigEndian.PutUint32(size[:], uint32(len(data)))
+
+	if _, err := s.w.Write(size[:]); err != nil {
+		return err
+	}
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+
+	s.count++
+	return nil
+}
+
+// replay calls fn with every stored write, in order, until fn returns false.
+func (s *spillFile) replay(fn func(data []byte) bool) error {
+	if err := s.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	r := bufio.NewReader(s.file)
+	var size [4]byte
+	for range s.count {
+		if _, err := io.ReadFull(r, size[:]); err != nil {
+			return err
+		}
+
+		data := make([]byte, binary.BigEndian.Uint32(size[:]))
+		if _, err := io.ReadFull(r, data); err != nil {
+			return err
+		}
+
+		if !fn(data) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// remove closes and deletes the file.
+func (s *spillFile) remove() {
+	s.file.Close()
+	os.Remove(s.file.Name())
+}
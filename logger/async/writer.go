@@ -1,98 +1,375 @@
 package async
 
 import (
+	"context"
+	"fmt"
 	"io"
+	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// ErrClosed is returned by Write after the AsyncWriter has been closed.
+//
+// It wraps io.ErrClosedPipe, which earlier versions returned, so
+// errors.Is(err, io.ErrClosedPipe) still reports closed writers.
+var ErrClosed = fmt.Errorf("async: write to closed writer: %w", io.ErrClosedPipe)
+
+// writers holds the AsyncWriters that were not closed yet, for FlushAll.
+var writers sync.Map
+
 // AsyncWriter is an io.Writer that processes writes asynchronously.
+//
+// It is safe for concurrent use. Write, Flush and Close may be called from
+// different goroutines at any time.
 type AsyncWriter struct {
-	target      io.Writer      // The actual destination writer
-	dataCh      chan []byte    // Channel for the data to be written
-	done        chan any       // Signal channel for shutdown
-	wg          sync.WaitGroup // To ensure all writes complete
-	closed      bool
-	forceClosed bool
-	bufSize     int
+	target   io.Writer     // The actual destination writer
+	opts     Options       // Settings with the defaults applied
+	wake     chan struct{} // Signals the background goroutine that there is work
+	done     chan struct{} // Closed when the background goroutine exits
+	discard  atomic.Bool   // Set when the pending writes must be dropped
+	dropped  atomic.Uint64 // Number of writes dropped by the overflow policy
+	targetMu sync.Mutex    // Serialises the writes to the target writer
+	batch    []byte        // Buffer coalescing the pending writes, used with targetMu held
+
+	mu       sync.Mutex // Guards the fields below
+	flushed  sync.Cond  // Signals that pending writes were processed
+	notFull  sync.Cond  // Signals that there is room in the queue
+	queue    queue      // Pending writes
+	spill    *spillFile // Pending writes that overflowed the queue, newer than the queued ones
+	enqueued uint64     // Number of writes queued since creation
+	written  uint64     // Number of queued writes processed since creation
+	closed   bool
+	urgent   bool // Set when the pending writes must be written without waiting
+	stopped  bool // Set when the background goroutine exits
 }
 
 // NewAsyncWriter creates a new AsyncWriter that writes to the target writer asynchronously.
 // bufferSize determines the number of pending writes that can be queued before blocking.
+//
+// When the buffer is full, the data is written directly to the target writer.
+// Use NewAsyncWriterWithOptions to choose another overflow policy.
 func NewAsyncWriter(target io.Writer, bufferSize int) *AsyncWriter {
-	if bufferSize <= 0 {
-		bufferSize = 100 // Default buffer size
+	return NewAsyncWriterWithOptions(target, Options{BufferSize: bufferSize})
+}
+
+// NewAsyncWriterWithOptions creates a new AsyncWriter configured by the given options.
+func NewAsyncWriterWithOptions(target io.Writer, opts Options) *AsyncWriter {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 100 // Default buffer size
+	}
+	if opts.SpillDir == "" {
+		opts.SpillDir = os.TempDir()
 	}
 
 	w := &AsyncWriter{
-		target:  target,
-		dataCh:  make(chan []byte, bufferSize),
-		done:    make(chan any),
-		bufSize: bufferSize,
+		target: target,
+		opts:   opts,
+		wake:   make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+	w.flushed.L = &w.mu
+	w.notFull.L = &w.mu
+	if opts.MaxBatchBytes > 0 {
+		w.batch = make([]byte, 0, opts.MaxBatchBytes)
 	}
 
-	w.wg.Add(1)
+	writers.Store(w, struct{}{})
 	go w.processWrites()
 
 	return w
 }
 
+// FlushAll flushes every AsyncWriter that is not closed.
+//
+// It is meant to be called before the process exits.
+func FlushAll() {
+	writers.Range(func(w, _ any) bool {
+		w.(*AsyncWriter).Flush()
+		return true
+	})
+}
+
 // Write implements io.Writer and sends data to be written asynchronously.
+//
+// It returns ErrClosed if the writer has been closed. If the buffer is full,
+// the overflow policy decides what happens to the data.
 func (w *AsyncWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+
+	if w.opts.Overflow == OverflowBlock {
+		for w.queue.len() >= w.opts.BufferSize && !w.closed {
+			w.notFull.Wait()
+		}
+	}
+
 	if w.closed {
-		return 0, io.ErrClosedPipe
+		w.mu.Unlock()
+		return 0, ErrClosed
 	}
 
-	// Make a copy of the data since p may be reused by the caller
-	length := len(p)
-	data := make([]byte, length)
-	copy(data, p)
+	if w.spill != nil || w.queue.len() >= w.opts.BufferSize {
+		return w.overflow(p)
+	}
+
+	w.enqueue(p)
+	w.mu.Unlock()
+	w.signal()
+
+	return len(p), nil
+}
+
+// enqueue appends a copy of the data to the queue. It must be called with mu held.
+func (w *AsyncWriter) enqueue(p []byte) {
+	// The queue copies the data since p may be reused by the caller
+	w.queue.push(p)
+	w.enqueued++
+}
+
+// overflow applies the overflow policy to the data. It must be called with mu
+// held, and releases it.
+func (w *AsyncWriter) overflow(p []byte) (n int, err error) {
+	switch w.opts.Overflow {
+	case OverflowDropNewest:
+		w.mu.Unlock()
+		w.dropped.Add(1)
+		return len(p), nil
+
+	case OverflowDropOldest:
+		w.queue.dropFront()
+		w.written++ // The dropped write counts as processed for Flush
+		w.dropped.Add(1)
+		w.enqueue(p)
+		w.flushed.Broadcast()
+		w.mu.Unlock()
+		w.signal()
+		return len(p), nil
+
+	case OverflowSpill:
+		if w.spill == nil {
+			if w.spill, err = newSpillFile(w.opts.SpillDir); err != nil {
+				w.mu.Unlock()
+				w.dropped.Add(1)
+				return 0, err
+			}
+		}
+		if err = w.spill.append(p); err != nil {
+			w.mu.Unlock()
+			w.dropped.Add(1)
+			return 0, err
+		}
+		w.enqueued++
+		w.mu.Unlock()
+		w.signal()
+		return len(p), nil
 
-	// Try to send without blocking
-	select {
-	case w.dataCh <- data:
-		return length, nil
 	default:
-		// Channel is full, write directly to target
+		// Write directly to target, after any write in progress
+		w.mu.Unlock()
+		w.targetMu.Lock()
+		defer w.targetMu.Unlock()
 		return w.target.Write(p)
 	}
 }
 
-// processWrites reads from the channel and writes to the target writer.
+// signal wakes up the background goroutine without blocking.
+func (w *AsyncWriter) signal() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// processWrites takes the pending writes and writes them to the target writer,
+// coalescing them into batches if MaxBatchBytes is set.
+//
+// It exits once the writer is closed and nothing is pending.
 func (w *AsyncWriter) processWrites() {
-	defer w.wg.Done()
+	defer func() {
+		writers.Delete(w)
+
+		w.mu.Lock()
+		w.stopped = true
+		w.flushed.Broadcast()
+		w.notFull.Broadcast()
+		w.mu.Unlock()
+		close(w.done)
+	}()
+
+	// The queue being written is swapped with the spare one, so both buffers are reused
+	var spare queue
+
+	for range w.wake {
+		if w.opts.FlushInterval > 0 && !w.ready() {
+			w.awaitBatch()
+		}
+
+		w.mu.Lock()
+		pending, spill := w.queue, w.spill
+		w.queue, w.spill = spare, nil
+		closed := w.closed
+		w.urgent = false
+		w.notFull.Broadcast()
+		w.mu.Unlock()
+
+		processed := pending.len()
+
+		w.targetMu.Lock()
+		pending.each(func(data []byte) bool {
+			if w.discard.Load() {
+				return false
+			}
+			w.writeBatched(data)
+			return true
+		})
+		if spill != nil {
+			processed += spill.count
+			w.replay(spill)
+		}
+		w.flushBatch()
+		w.targetMu.Unlock()
+
+		pending.reset()
+		spare = pending
+
+		w.mu.Lock()
+		w.written += uint64(processed)
+		w.flushed.Broadcast()
+		w.mu.Unlock()
+
+		// No write can be queued after closing, so nothing is pending
+		if closed {
+			return
+		}
+	}
+}
+
+// ready reports whether the pending writes must be written without waiting for more.
+func (w *AsyncWriter) ready() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.closed || w.urgent || w.spill != nil ||
+		w.queue.size() >= w.opts.MaxBatchBytes || w.queue.len() >= w.opts.BufferSize
+}
+
+// awaitBatch waits for the flush interval, or until the pending writes are ready.
+func (w *AsyncWriter) awaitBatch() {
+	timer := time.NewTimer(w.opts.FlushInterval)
+	defer timer.Stop()
 
 	for {
 		select {
-		case data := <-w.dataCh:
-			w.target.Write(data)
-		case <-w.done:
-			// Process any remaining writes
-			close(w.dataCh)
-			if !w.forceClosed {
-				for data := range w.dataCh {
-					w.target.Write(data)
-				}
+		case <-timer.C:
+			return
+		case <-w.wake:
+			if w.ready() {
+				return
 			}
+		}
+	}
+}
+
+// writeBatched appends the data to the batch, writing the batch first if the data
+// does not fit. Data larger than the batch is written on its own. It must be
+// called with targetMu held.
+func (w *AsyncWriter) writeBatched(data []byte) {
+	if len(w.batch)+len(data) > cap(w.batch) {
+		w.flushBatch()
+		if len(data) > cap(w.batch) {
+			w.target.Write(data)
 			return
 		}
 	}
+	w.batch = append(w.batch, data...)
+}
+
+// flushBatch writes the batch to the target writer. It must be called with targetMu held.
+func (w *AsyncWriter) flushBatch() {
+	if len(w.batch) > 0 {
+		w.target.Write(w.batch)
+		w.batch = w.batch[:0]
+	}
+}
+
+// replay writes the spilled writes to the target writer and removes the spill file.
+//
+// The writes that cannot be read back are counted as dropped.
+func (w *AsyncWriter) replay(spill *spillFile) {
+	defer spill.remove()
+
+	var replayed int
+	err := spill.replay(func(data []byte) bool {
+		if w.discard.Load() {
+			return false
+		}
+		w.writeBatched(data)
+		replayed++
+		return true
+	})
+
+	if err != nil {
+		w.dropped.Add(uint64(spill.count - replayed))
+	}
+}
+
+// Dropped returns the number of writes dropped by the overflow policy since the
+// writer was created or the counter was reset.
+func (w *AsyncWriter) Dropped() uint64 {
+	return w.dropped.Load()
 }
 
-// Flush waits for all pending writes to complete.
+// ResetDropped returns the number of dropped writes and resets the counter.
+//
+// It is meant to report, from time to time, how many messages were lost.
+func (w *AsyncWriter) ResetDropped() uint64 {
+	return w.dropped.Swap(0)
+}
+
+// Flush waits for the writes queued before the call to reach the target writer.
+//
+// The writer keeps accepting writes during and after the flush. Writes queued
+// by other goroutines while flushing are not waited for.
 func (w *AsyncWriter) Flush() {
-	if !w.closed {
-		w.closed = true
-		close(w.done)
-		w.wg.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.urgent = true
+	w.signal()
+
+	for target := w.enqueued; w.written < target && !w.stopped; {
+		w.flushed.Wait()
 	}
 }
 
-// Close closes the AsyncWriter without waiting for all pending writes to complete.
-func (w *AsyncWriter) Close() {
-	if !w.closed {
-		w.closed = true
-		w.forceClosed = true
-		close(w.done)
+// Close closes the writer and waits for all pending writes to complete.
+//
+// The writer must not be used afterwards, as writes return ErrClosed.
+// It is equivalent to CloseContext(context.Background()).
+func (w *AsyncWriter) Close() error {
+	return w.CloseContext(context.Background())
+}
+
+// CloseContext closes the writer and waits for the pending writes to complete
+// or for the context to be done, whichever happens first.
+//
+// Once the context is done, the writes still pending are discarded and the
+// context error is returned. Writes after the first call return ErrClosed.
+// Use an already canceled context to close without waiting.
+func (w *AsyncWriter) CloseContext(ctx context.Context) error {
+	w.mu.Lock()
+	w.closed = true
+	w.notFull.Broadcast()
+	w.mu.Unlock()
+	w.signal()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.discard.Store(true)
+		return ctx.Err()
 	}
 }
 
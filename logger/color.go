@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"io"
+	"os"
+	"sync"
+)
+
+// ColorMode selects when ColorTextHandler colors its output.
+type ColorMode uint8
+
+const (
+	// ColorAuto colors the output written to os.Stdout or os.Stderr when it is a
+	// terminal. The NO_COLOR environment variable disables the colors, and the
+	// FORCE_COLOR environment variable enables them for any output.
+	ColorAuto ColorMode = iota
+	// ColorAlways colors the output.
+	ColorAlways
+	// ColorNever does not color the output.
+	ColorNever
+)
+
+// Default colors of ColorTextHandler, as ANSI SGR parameters.
+const (
+	defaultTimeColor = "2" // Dim
+	defaultNameColor = "1" // Bold
+)
+
+// ColorTextHandler formats records like TextHandler, coloring the level name,
+// dimming the timestamp and highlighting the logger name.
+//
+// Uncolored output is byte-for-byte the output of TextHandler.
+// Colors are ANSI SGR parameters, such as "31" for red or "1;33" for bold yellow;
+// "0" leaves a part uncolored.
+type ColorTextHandler struct {
+	// Mode selects when the output is colored. The default is ColorAuto.
+	Mode ColorMode
+	// Colors are the colors of the level names. The levels without a color in
+	// the map use the color set by RegisterLevel.
+	Colors map[Level]string
+	// TimeColor is the color of the timestamp. The default is dim.
+	TimeColor string
+	// NameColor is the color of the logger name. The default is bold.
+	NameColor string
+}
+
+// NewColorTextHandler creates a ColorTextHandler with the given mode and the default colors.
+func NewColorTextHandler(mode ColorMode) *ColorTextHandler {
+	return &ColorTextHandler{Mode: mode}
+}
+
+// Handle formats the record and writes it to w with a single Write call.
+func (h *ColorTextHandler) Handle(w io.Writer, r *Record) {
+	if !h.colored(w) {
+		TextHandler{}.Handle(w, r)
+		return
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	colors := textColors{time: h.TimeColor, level: h.Colors[r.Level], name: h.NameColor}
+	if colors.time == "" {
+		colors.time = defaultTimeColor
+	}
+	if colors.level == "" {
+		colors.level = levelColor(r.Level)
+	}
+	if colors.name == "" {
+		colors.name = defaultNameColor
+	}
+
+	*buf = appendText(*buf, r, colors)
+	w.Write(*buf)
+}
+
+// colored reports whether the output written to w is colored.
+func (h *ColorTextHandler) colored(w io.Writer) bool {
+	switch h.Mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" && force != "0" && force != "false" {
+		return true
+	}
+	return isStdTerminal(w)
+}
+
+// ColorLogDispatcher formats and writes log messages like DefaultLogDispatcher,
+// colored when written to a terminal.
+//
+// It is the LogDispatcher form of a ColorTextHandler in ColorAuto mode.
+func ColorLogDispatcher(w io.Writer, dateFormat string, name string, l Level, a ...any) {
+	handleDispatch(&ColorTextHandler{}, w, dateFormat, name, l, a)
+}
+
+// terminals caches whether the file descriptors of os.Stdout and os.Stderr are terminals.
+var terminals sync.Map // map[uintptr]bool
+
+// isStdTerminal reports whether w is os.Stdout or os.Stderr, directly or behind
+// an asynchronous writer, and is a terminal.
+func isStdTerminal(w io.Writer) bool {
+	for {
+		target, ok := w.(interface{ Target() io.Writer })
+		if !ok {
+			break
+		}
+		w = target.Target()
+	}
+
+	f, ok := w.(*os.File)
+	if !ok || f != os.Stdout && f != os.Stderr {
+		return false
+	}
+
+	fd := f.Fd()
+	if terminal, ok := terminals.Load(fd); ok {
+		return terminal.(bool)
+	}
+	terminal := isTerminal(fd)
+	terminals.Store(fd, terminal)
+	return terminal
+}
+
+// appendColorStart appends the escape sequence that starts the color, if any.
+func appendColorStart(buf []byte, color string) []byte {
+	if color == "" {
+		return buf
+	}
+	buf = append(buf, "\x1b["...)
+	buf = append(buf, color...)
+	return append(buf, 'm')
+}
+
+// appendColorEnd appends the escape sequence that resets the color, if any.
+func appendColorEnd(buf []byte, color string) []byte {
+	if color == "" {
+		return buf
+	}
+	return append(buf, "\x1b[0m"...)
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ecromaneli-golang/console/logger/async"
+)
+
+var (
+	exitOnFatal  atomic.Bool
+	exitMu       sync.Mutex
+	exitHandlers []func()
+	exitFunc     = os.Exit
+	exiting      atomic.Bool // Set while Exit runs, so a nested call does not run the handlers again
+)
+
+// SetExitOnFatal sets whether the Fatal methods terminate the process.
+//
+// When enabled, Fatal, Fatalf and Fatalw call Exit(1) after logging, even if
+// the fatal level is disabled. It is disabled by default.
+func SetExitOnFatal(enabled bool) {
+	exitOnFatal.Store(enabled)
+}
+
+// RegisterExitHandler adds a function to be called by Exit before the process terminates.
+//
+// The handlers are called in the order they were registered.
+func RegisterExitHandler(handler func()) {
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitHandlers = append(exitHandlers, handler)
+}
+
+// SetExitFunc sets the function called by Exit to terminate the process.
+//
+// It defaults to os.Exit, and nil restores the default. Tests can use it to
+// intercept the termination.
+func SetExitFunc(exit func(code int)) {
+	if exit == nil {
+		exit = os.Exit
+	}
+
+	exitMu.Lock()
+	defer exitMu.Unlock()
+	exitFunc = exit
+}
+
+// Exit flushes every asynchronous output, calls the exit handlers and
+// terminates the process with the given status code.
+//
+// A panicking handler does not prevent the following ones from being called.
+// The outputs are flushed again after the handlers, as they may log. A call
+// made by a handler, such as a Fatal message with SetExitOnFatal enabled,
+// only flushes the outputs and terminates the process.
+func Exit(code int) {
+	exitMu.Lock()
+	handlers := exitHandlers[:len(exitHandlers):len(exitHandlers)]
+	exit := exitFunc
+	exitMu.Unlock()
+
+	if !exiting.CompareAndSwap(false, true) {
+		async.FlushAll()
+		exit(code)
+		return
+	}
+	// The exit function only returns when it is replaced, as in tests
+	defer exiting.Store(false)
+
+	async.FlushAll()
+	for _, handler := range handlers {
+		runExitHandler(handler)
+	}
+	async.FlushAll()
+
+	exit(code)
+}
+
+// runExitHandler calls the handler, recovering from any panic.
+func runExitHandler(handler func()) {
+	defer func() {
+		recover()
+	}()
+	handler()
+}
+
+// exitIfFatal calls Exit(1) if the Fatal methods must terminate the process.
+func exitIfFatal() {
+	if exitOnFatal.Load() {
+		Exit(1)
+	}
+}
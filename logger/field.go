@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// badKey is the key used for values that have no matching key.
+const badKey = "!BADKEY"
+
+// Field is a structured key/value pair attached to a log message.
+//
+// Fields reach the dispatcher as typed values among its arguments, so
+// dispatchers can render them as data instead of plain text.
+type Field struct {
+	Key   string
+	Value any
+}
+
+// String returns the field formatted as key=value.
+//
+// The value is quoted when it is empty or contains spaces, quotes or '='.
+func (f Field) String() string {
+	return string(appendField(nil, f))
+}
+
+// appendField appends the field formatted as key=value.
+func appendField(buf []byte, f Field) []byte {
+	buf = append(buf, f.Key...)
+	buf = append(buf, '=')
+
+	start := len(buf)
+	buf = appendValue(buf, f.Value)
+	if value := buf[start:]; len(value) == 0 || bytes.ContainsAny(value, " \t\r\n\"=") {
+		buf = strconv.AppendQuote(buf[:start], string(value))
+	}
+	return buf
+}
+
+// appendValue appends the value formatted as by fmt.Sprint.
+//
+// Strings, fields and basic numeric types are appended without going through fmt.
+func appendValue(buf []byte, v any) []byte {
+	switch v := v.(type) {
+	case string:
+		return append(buf, v...)
+	case Field:
+		return appendField(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case bool:
+		return strconv.AppendBool(buf, v)
+	default:
+		return fmt.Append(buf, v)
+	}
+}
+
+// SplitFields separates the structured fields from the remaining arguments.
+//
+// It is intended for dispatchers that render fields apart from the message.
+func SplitFields(a []any) (args []any, fields []Field) {
+	for i, arg := range a {
+		if _, ok := arg.(Field); !ok {
+			continue
+		}
+
+		// Only allocate when fields are present
+		args = make([]any, 0, len(a))
+		args = append(args, a[:i]...)
+		for _, arg := range a[i:] {
+			if field, ok := arg.(Field); ok {
+				fields = append(fields, field)
+			} else {
+				args = append(args, arg)
+			}
+		}
+		return args, fields
+	}
+
+	return a, nil
+}
+
+// fieldsFromKeyvals converts alternating keys and values into fields.
+func fieldsFromKeyvals(keyvals []any) []Field {
+	if len(keyvals) == 0 {
+		return nil
+	}
+
+	return appendKeyvals(make([]Field, 0, (len(keyvals)+1)/2), keyvals)
+}
+
+// appendKeyvals converts alternating keys and values into fields and appends them.
+//
+// Field values are taken as they are. A key that is not a string, or a
+// trailing key without value, is stored under the "!BADKEY" key.
+func appendKeyvals(fields []Field, keyvals []any) []Field {
+	for i := 0; i < len(keyvals); {
+		switch key := keyvals[i].(type) {
+		case Field:
+			fields = append(fields, key)
+			i++
+		case string:
+			if i+1 == len(keyvals) {
+				fields = append(fields, Field{Key: badKey, Value: key})
+				i++
+			} else {
+				fields = append(fields, Field{Key: key, Value: keyvals[i+1]})
+				i += 2
+			}
+		default:
+			fields = append(fields, Field{Key: badKey, Value: key})
+			i++
+		}
+	}
+
+	return fields
+}
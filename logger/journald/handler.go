@@ -0,0 +1,132 @@
+package journald
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ecromaneli-golang/console/logger"
+	"github.com/ecromaneli-golang/console/logger/syslog"
+)
+
+// Options configures a Handler.
+type Options struct {
+	// Identifier is the SYSLOG_IDENTIFIER of the entries. Defaults to the logger
+	// name, or to the program name if the logger name is empty. When set, the
+	// logger name is the LOGGER field of the entries.
+	Identifier string
+}
+
+// Handler is a logger.Handler that formats records as journal entries of the
+// native journald protocol.
+//
+// An entry has the MESSAGE, PRIORITY and SYSLOG_IDENTIFIER fields, the
+// CODE_FILE, CODE_LINE and CODE_FUNC fields if the call site was captured, the
+// STACK_TRACE field if a stack trace was captured, and the record fields. The
+// record field names are converted to journal field names: upper case letters,
+// digits and underscores, not starting with an underscore or a digit.
+//
+// Each record is written to the logger output with a single Write call, so the
+// output is expected to be a Writer, which sends every write as an entry. An
+// async.AsyncWriter in front of the Writer must not coalesce the writes, so its
+// MaxBatchBytes option must be left at zero.
+type Handler struct {
+	opts    Options
+	bufPool sync.Pool
+}
+
+// NewHandler creates a Handler with the given options.
+func NewHandler(opts Options) *Handler {
+	return &Handler{
+		opts: opts,
+		bufPool: sync.Pool{New: func() any {
+			buf := make([]byte, 0, 1024)
+			return &buf
+		}},
+	}
+}
+
+// Handle formats the record as a journal entry and writes it to w.
+func (h *Handler) Handle(w io.Writer, r *logger.Record) {
+	buf := h.bufPool.Get().(*[]byte)
+	defer func() {
+		*buf = (*buf)[:0]
+		h.bufPool.Put(buf)
+	}()
+
+	*buf = appendJournalField(*buf, "MESSAGE", string(r.AppendMessage(nil)))
+	*buf = appendJournalField(*buf, "PRIORITY", strconv.Itoa(int(syslog.SeverityOf(r.Level))))
+
+	switch {
+	case h.opts.Identifier != "":
+		*buf = appendJournalField(*buf, "SYSLOG_IDENTIFIER", h.opts.Identifier)
+		if r.Name != "" {
+			*buf = appendJournalField(*buf, "LOGGER", r.Name)
+		}
+	case r.Name != "":
+		*buf = appendJournalField(*buf, "SYSLOG_IDENTIFIER", r.Name)
+	default:
+		*buf = appendJournalField(*buf, "SYSLOG_IDENTIFIER", filepath.Base(os.Args[0]))
+	}
+
+	if frame := r.Caller(); frame.File != "" {
+		*buf = appendJournalField(*buf, "CODE_FILE", frame.File)
+		*buf = appendJournalField(*buf, "CODE_LINE", strconv.Itoa(frame.Line))
+		*buf = appendJournalField(*buf, "CODE_FUNC", frame.Function)
+	}
+	if r.Stack != "" {
+		*buf = appendJournalField(*buf, "STACK_TRACE", r.Stack)
+	}
+
+	for _, field := range r.Fields {
+		value, ok := field.Value.(string)
+		if !ok {
+			value = fmt.Sprint(field.Value)
+		}
+		*buf = appendJournalField(*buf, fieldName(field.Key), value)
+	}
+
+	w.Write(*buf)
+}
+
+// appendJournalField appends a field in the native protocol: "NAME=value\n", or
+// the name followed by the little endian 64-bit length of the value and the
+// value if it contains a newline.
+func appendJournalField(buf []byte, name string, value string) []byte {
+	buf = append(buf, name...)
+	if strings.IndexByte(value, '\n') < 0 {
+		buf = append(buf, '=')
+	} else {
+		buf = append(buf, '\n')
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(len(value)))
+	}
+	buf = append(buf, value...)
+	return append(buf, '\n')
+}
+
+// fieldName converts a key to a journal field name, made of at most 64 upper
+// case letters, digits and underscores, and not starting with an underscore or a digit.
+func fieldName(key string) string {
+	name := make([]byte, 0, len(key))
+	for i := 0; i < len(key); i++ {
+		switch c := key[i]; {
+		case 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+			name = append(name, c)
+		case 'a' <= c && c <= 'z':
+			name = append(name, c-'a'+'A')
+		default:
+			name = append(name, '_')
+		}
+	}
+
+	trimmed := strings.TrimLeft(string(name), "_0123456789")
+	if trimmed == "" {
+		return "FIELD"
+	}
+	return trimmed[:min(len(trimmed), 64)]
+}
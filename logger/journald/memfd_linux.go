@@ -0,0 +1,85 @@
+package journald
+
+import (
+	"errors"
+	"net"
+	"os"
+	"runtime"
+	"syscall"
+	"unsafe"
+)
+
+// memfdCreateTrap is the number of the memfd_create system call on each architecture.
+var memfdCreateTrap = map[string]uintptr{
+	"386":      356,
+	"amd64":    319,
+	"arm":      385,
+	"arm64":    279,
+	"loong64":  279,
+	"mips":     4354,
+	"mipsle":   4354,
+	"mips64":   5314,
+	"mips64le": 5314,
+	"ppc64":    360,
+	"ppc64le":  360,
+	"riscv64":  279,
+	"s390x":    350,
+}
+
+// The flags of memfd_create and the seals of fcntl.
+const (
+	mfdCloexec      = 0x1
+	mfdAllowSealing = 0x2
+	fAddSeals       = 0x409
+	fSealAll        = 0x1 | 0x2 | 0x4 | 0x8 // F_SEAL_SEAL, F_SEAL_SHRINK, F_SEAL_GROW and F_SEAL_WRITE
+)
+
+// send sends the entry as a datagram, or as a memory file if it is too large for a datagram.
+func send(conn *net.UnixConn, addr *net.UnixAddr, p []byte) error {
+	_, err := conn.WriteToUnix(p, addr)
+	if errors.Is(err, syscall.EMSGSIZE) || errors.Is(err, syscall.ENOBUFS) {
+		err = sendLarge(conn, addr, p)
+	}
+	return err
+}
+
+// sendLarge writes the entry to a sealed memory file and sends its descriptor.
+// It falls back to an unlinked file in /dev/shm if memory files are not supported.
+func sendLarge(conn *net.UnixConn, addr *net.UnixAddr, p []byte) error {
+	file, sealed, err := createMemoryFile()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(p); err != nil {
+		return err
+	}
+	if sealed {
+		if _, _, errno := syscall.Syscall(syscall.SYS_FCNTL, file.Fd(), fAddSeals, fSealAll); errno != 0 {
+			return errno
+		}
+	}
+
+	_, _, err = conn.WriteMsgUnix(nil, syscall.UnixRights(int(file.Fd())), addr)
+	return err
+}
+
+// createMemoryFile creates a memory file that can be sealed, reporting whether it can,
+// or an unlinked file in /dev/shm.
+func createMemoryFile() (*os.File, bool, error) {
+	if trap, ok := memfdCreateTrap[runtime.GOARCH]; ok {
+		name := []byte("journal-entry\x00")
+		fd, _, errno := syscall.Syscall(trap, uintptr(unsafe.Pointer(&name[0])), mfdCloexec|mfdAllowSealing, 0)
+		if errno == 0 {
+			return os.NewFile(fd, "journal-entry"), true, nil
+		}
+	}
+
+	file, err := os.CreateTemp("/dev/shm", "journal-entry")
+	if err != nil {
+		return nil, false, err
+	}
+	os.Remove(file.Name())
+	return file, false, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package journald
+
+import "net"
+
+// send sends the entry as a datagram. Entries too large for a datagram are not
+// sent as memory files, as journald only runs on Linux.
+func send(conn *net.UnixConn, addr *net.UnixAddr, p []byte) error {
+	_, err := conn.WriteToUnix(p, addr)
+	return err
+}
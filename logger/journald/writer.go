@@ -0,0 +1,74 @@
+package journald
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+)
+
+// SocketPath is the path of the socket of the native journald protocol.
+const SocketPath = "/run/systemd/journal/socket"
+
+// ErrClosed is returned by Write after the Writer has been closed.
+var ErrClosed = errors.New("journald: write to closed writer")
+
+// Writer is an io.Writer that sends each write as a journal entry, in the
+// native journald protocol.
+//
+// On Linux, entries too large for a datagram are written to a sealed memory
+// file whose descriptor is sent instead, as journald expects.
+//
+// It is safe for concurrent use.
+type Writer struct {
+	addr   *net.UnixAddr
+	mu     sync.Mutex // Guards the fields below
+	conn   *net.UnixConn
+	closed bool
+}
+
+// Dial opens a socket to the journald socket at the path, or at SocketPath if the path is empty.
+//
+// The socket is not connected, as sending a descriptor requires an unconnected
+// datagram socket, so Dial only checks that the journald socket exists.
+func Dial(path string) (*Writer, error) {
+	if path == "" {
+		path = SocketPath
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{addr: &net.UnixAddr{Name: path, Net: "unixgram"}, conn: conn}, nil
+}
+
+// Write sends p as a single journal entry.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	if err := send(w.conn, w.addr, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection. Writes after Close return ErrClosed.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	return w.conn.Close()
+}
@@ -0,0 +1,339 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONKeys defines the key names used by JSONHandler, and how the time and the
+// level are written under them.
+//
+// An empty key omits the corresponding entry from the output.
+type JSONKeys struct {
+	Time    string
+	Level   string
+	Logger  string
+	Message string
+	// Template is the key of the printf-style template of formatted messages,
+	// useful to group messages that only differ in their arguments.
+	Template string
+	// Caller is the key of the call site, as "dir/file.go:line", when captured.
+	Caller string
+	// Function is the key of the function name of the call site, when captured.
+	Function string
+	// Stack is the key of the stack trace, when captured.
+	Stack string
+	// TimeFormat is the layout of the time, such as time.RFC3339Nano. Defaults to
+	// the date format of the logger. The time is omitted when the date format of
+	// the logger is empty.
+	TimeFormat string
+	// LevelName returns the value written under the Level key. Defaults to Level.String.
+	LevelName func(Level) string
+}
+
+// fieldKeyPrefix is prepended to the keys of the fields that collide with a built-in key.
+const fieldKeyPrefix = "fields."
+
+var (
+	// DefaultJSONKeys is the key schema used by JSONLogDispatcher.
+	DefaultJSONKeys = JSONKeys{Time: "time", Level: "level", Logger: "logger", Message: "msg", Caller: "caller", Stack: "stack"}
+	// ECSJSONKeys follows the Elastic Common Schema.
+	ECSJSONKeys = JSONKeys{Time: "@timestamp", Level: "log.level", Logger: "log.logger", Message: "message", Caller: "caller", Function: "log.origin.function", Stack: "error.stack_trace",
+		TimeFormat: time.RFC3339Nano}
+	// GCPJSONKeys follows the Google Cloud Logging structured payload.
+	GCPJSONKeys = JSONKeys{Time: "time", Level: "severity", Logger: "logger", Message: "message", Caller: "caller", Stack: "stack_trace",
+		TimeFormat: time.RFC3339Nano, LevelName: GCPSeverity}
+)
+
+// GCPSeverity returns the Google Cloud Logging severity of the level.
+//
+// The levels between two predefined levels take the severity of the more verbose one,
+// except those between LevelWarn and LevelInfo, which are NOTICE.
+func GCPSeverity(lv Level) string {
+	switch {
+	case lv == LevelOff:
+		return "DEFAULT"
+	case lv <= LevelFatal:
+		return "CRITICAL"
+	case lv <= LevelError:
+		return "ERROR"
+	case lv <= LevelWarn:
+		return "WARNING"
+	case lv < LevelInfo:
+		return "NOTICE"
+	case lv == LevelInfo:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
+
+// JSONHandler writes each record as one JSON object per line.
+//
+// The time is formatted with the time format of the keys, or with the logger
+// date format, and omitted when the logger date format is empty. Fields are
+// added as top-level entries, and the keys of the fields that collide with a
+// built-in key are prefixed with "fields.".
+type JSONHandler struct {
+	// Keys are the key names of the built-in entries.
+	Keys JSONKeys
+}
+
+// NewJSONHandler creates a JSON handler using the given key names.
+func NewJSONHandler(keys JSONKeys) *JSONHandler {
+	return &JSONHandler{Keys: keys}
+}
+
+// Handle formats the record as a JSON object and writes it to w.
+func (h *JSONHandler) Handle(w io.Writer, r *Record) {
+	keys := &h.Keys
+
+	pooled := getBuffer()
+	defer putBuffer(pooled)
+
+	buf := append(*pooled, '{')
+
+	if keys.Time != "" && r.DateFormat != "" {
+		layout := keys.TimeFormat
+		if layout == "" {
+			layout = r.DateFormat
+		}
+		buf = appendJSONKey(buf, keys.Time)
+		buf = appendJSONString(buf, r.Time.Format(layout))
+	}
+
+	if keys.Level != "" {
+		buf = appendJSONKey(buf, keys.Level)
+		if keys.LevelName != nil {
+			buf = appendJSONString(buf, keys.LevelName(r.Level))
+		} else {
+			buf = appendJSONString(buf, r.Level.String())
+		}
+	}
+
+	if keys.Logger != "" && r.Name != "" {
+		buf = appendJSONKey(buf, keys.Logger)
+		buf = appendJSONString(buf, r.Name)
+	}
+
+	if keys.Message != "" {
+		buf = appendJSONKey(buf, keys.Message)
+		buf = appendJSONString(buf, r.Message())
+	}
+
+	if keys.Caller != "" && r.PC != 0 {
+		buf = appendJSONKey(buf, keys.Caller)
+		buf = appendJSONString(buf, string(r.AppendCaller(nil)))
+	}
+
+	if keys.Function != "" && r.PC != 0 {
+		buf = appendJSONKey(buf, keys.Function)
+		buf = appendJSONString(buf, r.Caller().Function)
+	}
+
+	if keys.Stack != "" && r.Stack != "" {
+		buf = appendJSONKey(buf, keys.Stack)
+		buf = appendJSONString(buf, r.Stack)
+	}
+
+	if keys.Template != "" && r.Format != "" {
+		buf = appendJSONKey(buf, keys.Template)
+		buf = appendJSONString(buf, r.Format)
+	}
+
+	for _, field := range r.Fields {
+		if keys.isBuiltin(field.Key) {
+			buf = appendJSONKey(buf, fieldKeyPrefix+field.Key)
+		} else {
+			buf = appendJSONKey(buf, field.Key)
+		}
+		buf = appendJSONValue(buf, field.Value)
+	}
+
+	buf = append(buf, '}', '\n')
+	w.Write(buf)
+	*pooled = buf
+}
+
+// JSONLogDispatcher is the LogDispatcher form of a JSONHandler using DefaultJSONKeys.
+func JSONLogDispatcher(w io.Writer, dateFormat string, name string, l Level, a ...any) {
+	handleDispatch(NewJSONHandler(DefaultJSONKeys), w, dateFormat, name, l, a)
+}
+
+// NewJSONLogDispatcher creates the LogDispatcher form of a JSONHandler using the given key names.
+func NewJSONLogDispatcher(keys JSONKeys) LogDispatcher {
+	return dispatcherOf(NewJSONHandler(keys))
+}
+
+// isBuiltin reports whether the key is one of the keys of the built-in entries.
+func (k *JSONKeys) isBuiltin(key string) bool {
+	switch key {
+	case "":
+		return false
+	case k.Time, k.Level, k.Logger, k.Message, k.Template, k.Caller, k.Function, k.Stack:
+		return true
+	}
+	return false
+}
+
+// appendJSONKey appends the separator, if needed, and the quoted key followed by a colon.
+func appendJSONKey(buf []byte, key string) []byte {
+	if buf[len(buf)-1] != '{' {
+		buf = append(buf, ',')
+	}
+	buf = appendJSONString(buf, key)
+	return append(buf, ':')
+}
+
+// appendJSONValue appends the JSON encoding of v.
+//
+// Errors, durations and fmt.Stringer values are encoded as strings, and
+// values that cannot be marshalled fall back to their fmt representation.
+func appendJSONValue(buf []byte, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case string:
+		return appendJSONString(buf, v)
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(buf, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case uint:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(buf, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(buf, v, 10)
+	case float32:
+		return appendJSONFloat(buf, float64(v), 32)
+	case float64:
+		return appendJSONFloat(buf, v, 64)
+	case time.Time:
+		return appendJSONString(buf, v.Format(time.RFC3339Nano))
+	case time.Duration:
+		return appendJSONString(buf, v.String())
+	}
+	return appendJSONAny(buf, v)
+}
+
+// appendJSONAny appends the JSON encoding of v by its MarshalJSON, Error or
+// String method, or by json.Marshal.
+//
+// A method that panics, as on a nil pointer, never reaches the caller: nil
+// pointers are encoded as null and other values fall back to their fmt representation.
+func appendJSONAny(buf []byte, v any) (out []byte) {
+	start := len(buf)
+	defer func() {
+		if recover() == nil {
+			return
+		}
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			out = append(buf[:start], "null"...)
+			return
+		}
+		out = appendJSONString(buf[:start], fmt.Sprint(v))
+	}()
+
+	switch v := v.(type) {
+	case json.Marshaler:
+		if data, err := v.MarshalJSON(); err == nil {
+			return append(buf, data...)
+		}
+	case error:
+		return appendJSONString(buf, v.Error())
+	case fmt.Stringer:
+		return appendJSONString(buf, v.String())
+	}
+
+	if data, err := json.Marshal(v); err == nil {
+		return append(buf, data...)
+	}
+	return appendJSONString(buf, fmt.Sprint(v))
+}
+
+// appendJSONFloat appends a JSON number, or a string for NaN and infinities.
+func appendJSONFloat(buf []byte, f float64, bitSize int) []byte {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return appendJSONString(buf, strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bitSize)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a quoted and escaped JSON string.
+//
+// Invalid UTF-8 sequences are replaced by U+FFFD.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+
+			buf = append(buf, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			default:
+				buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+
+		// U+2028 and U+2029 break JavaScript consumers
+		if r == '\u2028' || r == '\u2029' {
+			buf = append(buf, s[start:i]...)
+			buf = append(buf, '\\', 'u', '2', '0', '2', hexDigits[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+
+		i += size
+	}
+
+	buf = append(buf, s[start:]...)
+	return append(buf, '"')
+}
@@ -0,0 +1,289 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// Level represents the severity of a log message.
+type Level uint8
+
+const (
+	// LevelOff disables all logging.
+	LevelOff Level = 0
+	// LevelFatal is for critical errors that cause application failure.
+	LevelFatal Level = 5
+	// LevelError is for runtime errors that don't cause application failure.
+	LevelError Level = 10
+	// LevelWarn is for potentially harmful situations.
+	LevelWarn Level = 15
+	// LevelInfo is for general information messages.
+	LevelInfo Level = 20
+	// LevelDebug is for detailed debugging information.
+	LevelDebug Level = 25
+	// LevelTrace is for the most fine-grained information.
+	LevelTrace Level = 30
+	// LevelAll enables all possible logging levels.
+	LevelAll Level = 255
+)
+
+// LevelOptions are the optional settings of a level registered by RegisterLevel.
+type LevelOptions struct {
+	// Aliases are other names accepted by ParseLevel for the level.
+	Aliases []string
+	// Color is the ANSI SGR parameters of the level name in colored output, such as "35" for magenta.
+	Color string
+}
+
+// levelTable holds the names and colors of the registered levels.
+//
+// A table is never modified once published: RegisterLevel publishes an updated copy.
+type levelTable struct {
+	stringByLevel map[Level]string
+	levelByStr    map[string]Level // Upper case names and aliases
+	colorByLevel  map[Level]string
+}
+
+// levelWidth is the length of the longest predefined level name, to which the text handlers pad the level names.
+const levelWidth = 5
+
+var (
+	levels   atomic.Pointer[levelTable]
+	levelsMu sync.Mutex // Serialises the registration of levels
+)
+
+func init() {
+	levels.Store(&levelTable{
+		stringByLevel: map[Level]string{
+			LevelAll:   "ALL",
+			LevelTrace: "TRACE",
+			LevelDebug: "DEBUG",
+			LevelInfo:  "INFO",
+			LevelWarn:  "WARN",
+			LevelError: "ERROR",
+			LevelFatal: "FATAL",
+			LevelOff:   "OFF",
+		},
+		levelByStr: map[string]Level{
+			"ALL":     LevelAll,
+			"TRACE":   LevelTrace,
+			"DEBUG":   LevelDebug,
+			"INFO":    LevelInfo,
+			"WARN":    LevelWarn,
+			"WARNING": LevelWarn,
+			"ERROR":   LevelError,
+			"ERR":     LevelError,
+			"FATAL":   LevelFatal,
+			"CRIT":    LevelFatal,
+			"OFF":     LevelOff,
+		},
+		colorByLevel: map[Level]string{
+			LevelTrace: "90",
+			LevelDebug: "36",
+			LevelInfo:  "32",
+			LevelWarn:  "33",
+			LevelError: "31",
+			LevelFatal: "1;31",
+		},
+	})
+}
+
+// RegisterLevel registers the name of a custom level, such as RegisterLevel(17, "NOTICE", LevelOptions{}).
+//
+// The name is used by String and by the handlers, and is accepted by ParseLevel
+// in any case, along with the aliases in the options. Registering a level that
+// already has a name replaces the name, and the previous name remains accepted by
+// ParseLevel. The text handlers pad the level names to 5 characters, the length
+// of the longest predefined name, and write longer names without padding, so
+// registering a level never changes the layout of the other levels.
+//
+// Names start with a letter and contain only letters, digits and underscores.
+// It returns an error if a name is invalid or is already used by another level.
+func RegisterLevel(lv Level, name string, opts LevelOptions) error {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	current := levels.Load()
+	table := &levelTable{
+		stringByLevel: maps.Clone(current.stringByLevel),
+		levelByStr:    maps.Clone(current.levelByStr),
+		colorByLevel:  maps.Clone(current.colorByLevel),
+	}
+
+	for _, str := range append([]string{name}, opts.Aliases...) {
+		if !isLevelName(str) {
+			return fmt.Errorf("logger: invalid level name %q", str)
+		}
+		upper := strings.ToUpper(str)
+		if other, ok := table.levelByStr[upper]; ok && other != lv {
+			return fmt.Errorf("logger: level name %q is already used by %s", str, other)
+		}
+		table.levelByStr[upper] = lv
+	}
+
+	table.stringByLevel[lv] = name
+	if opts.Color != "" {
+		table.colorByLevel[lv] = opts.Color
+	}
+
+	levels.Store(table)
+	return nil
+}
+
+// UnregisterLevel removes the name, the aliases and the color of a custom level
+// registered by RegisterLevel, which is written as LEVEL(n) again.
+//
+// It returns an error for the predefined levels.
+func UnregisterLevel(lv Level) error {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	switch lv {
+	case LevelOff, LevelFatal, LevelError, LevelWarn, LevelInfo, LevelDebug, LevelTrace, LevelAll:
+		return fmt.Errorf("logger: cannot unregister predefined level %s", lv)
+	}
+
+	current := levels.Load()
+	table := &levelTable{
+		stringByLevel: maps.Clone(current.stringByLevel),
+		levelByStr:    maps.Clone(current.levelByStr),
+		colorByLevel:  maps.Clone(current.colorByLevel),
+	}
+	delete(table.stringByLevel, lv)
+	delete(table.colorByLevel, lv)
+	maps.DeleteFunc(table.levelByStr, func(_ string, other Level) bool {
+		return other == lv
+	})
+
+	levels.Store(table)
+	return nil
+}
+
+// isLevelName reports whether the string is a valid level name.
+func isLevelName(str string) bool {
+	for i, r := range str {
+		if !unicode.IsLetter(r) && (i == 0 || r != '_' && !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return str != ""
+}
+
+// String returns the string representation of the log level.
+//
+// Levels without a name are represented as LEVEL(n), where n is the level value.
+func (l Level) String() string {
+	if str, ok := levels.Load().stringByLevel[l]; ok {
+		return str
+	}
+	return "LEVEL(" + strconv.Itoa(int(l)) + ")"
+}
+
+// LevelFromString converts a string representation to a Level.
+//
+// It returns the corresponding Level for the given string value, or LevelOff if
+// the string is not a valid level. Use ParseLevel to detect invalid levels.
+func LevelFromString(level string) Level {
+	lv, _ := ParseLevel(level)
+	return lv
+}
+
+// ParseLevel converts a string representation to a Level.
+//
+// The string is a level name in any case, such as "info", one of the aliases
+// "WARNING", "ERR" and "CRIT", a level value such as "20", or the LEVEL(n) form
+// returned by String. It returns an error if the string is not a valid level.
+func ParseLevel(level string) (Level, error) {
+	if lv, ok := lookupLevel(level); ok {
+		return lv, nil
+	}
+	return LevelOff, fmt.Errorf("logger: unknown level %q", level)
+}
+
+// lookupLevel converts a string representation to a Level, reporting whether it is valid.
+func lookupLevel(level string) (Level, bool) {
+	upper := strings.ToUpper(level)
+	if lv, ok := levels.Load().levelByStr[upper]; ok {
+		return lv, true
+	}
+	if value, ok := strings.CutPrefix(upper, "LEVEL("); ok {
+		upper, ok = strings.CutSuffix(value, ")")
+		if !ok {
+			return LevelOff, false
+		}
+	}
+	if n, err := strconv.ParseUint(upper, 10, 8); err == nil {
+		return Level(n), true
+	}
+	return LevelOff, false
+}
+
+// appendLevelPadding appends the spaces that pad the name of the level to levelWidth.
+func appendLevelPadding(buf []byte, l Level) []byte {
+	n := levelWidth
+	if str, ok := levels.Load().stringByLevel[l]; ok {
+		n -= len(str)
+	} else {
+		n -= len(l.String())
+	}
+	for range n {
+		buf = append(buf, ' ')
+	}
+	return buf
+}
+
+// levelColor returns the ANSI SGR parameters of the level name in colored output.
+func levelColor(l Level) string {
+	return levels.Load().colorByLevel[l]
+}
+
+// MarshalText implements encoding.TextMarshaler, using the string representation of the level.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting the strings accepted by ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	lv, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = lv
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the level as a JSON string.
+func (l Level) MarshalJSON() ([]byte, error) {
+	return strconv.AppendQuote(nil, l.String()), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a JSON string with a
+// string accepted by ParseLevel, or a JSON number with a level value.
+// A JSON null leaves the level unchanged.
+func (l *Level) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		var value uint8
+		if json.Unmarshal(data, &value) != nil {
+			return fmt.Errorf("logger: invalid level %s", data)
+		}
+		*l = Level(value)
+		return nil
+	}
+	return l.UnmarshalText([]byte(text))
+}
+
+// Set implements flag.Value, accepting the strings accepted by ParseLevel.
+func (l *Level) Set(level string) error {
+	return l.UnmarshalText([]byte(level))
+}
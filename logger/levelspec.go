@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+var (
+	levelSpecMu       sync.Mutex
+	levelSpecNames    []string // Loggers whose level was set by the last level spec
+	levelSpecDefault  bool     // Set when the default level was set by the last level spec
+	defaultBeforeSpec Level    // Default level before it was set by a level spec
+)
+
+// SetLogLevelSpec sets the levels of the registered loggers from a spec string.
+//
+// The spec is a comma-separated list of name=level entries, such as
+// "info,db=debug,http.client=trace". An entry without a name sets the default
+// level, and the other entries set the level of the logger returned by Get with
+// that name, which is inherited by its children and by the loggers created by New.
+//
+// The spec replaces the previous one: loggers named only in the previous spec
+// inherit the level of their parent again, and if only the previous spec set the
+// default level, the default level it replaced is restored. If an entry is
+// invalid, no level is changed and the returned error describes every invalid entry.
+func SetLogLevelSpec(spec string) error {
+	levels, err := parseLevelSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	levelSpecMu.Lock()
+	defer levelSpecMu.Unlock()
+
+	for _, name := range levelSpecNames {
+		if _, ok := levels[name]; !ok {
+			Get(name).logLevel.Store(levelUnset)
+		}
+	}
+	if _, ok := levels[""]; !ok && levelSpecDefault {
+		SetDefaultLogLevel(defaultBeforeSpec)
+		levelSpecDefault = false
+	}
+
+	levelSpecNames = levelSpecNames[:0]
+	for name, lv := range levels {
+		if name == "" {
+			if !levelSpecDefault {
+				defaultsMu.RLock()
+				defaultBeforeSpec = DefaultLogLevel
+				defaultsMu.RUnlock()
+				levelSpecDefault = true
+			}
+			SetDefaultLogLevel(lv)
+			continue
+		}
+		Get(name).SetLogLevel(lv)
+		levelSpecNames = append(levelSpecNames, name)
+	}
+	return nil
+}
+
+// SetLogLevelSpecFromEnv sets the levels of the registered loggers from the spec
+// in the given environment variable, such as LOG_LEVEL.
+//
+// See SetLogLevelSpec for the format of the spec. An unset variable is an empty spec.
+func SetLogLevelSpecFromEnv(key string) error {
+	if err := SetLogLevelSpec(os.Getenv(key)); err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	return nil
+}
+
+// parseLevelSpec parses a level spec into the level of each logger name.
+// The default level has an empty name.
+func parseLevelSpec(spec string) (map[string]Level, error) {
+	levels := map[string]Level{}
+	var errs []error
+
+	for entry := range strings.SplitSeq(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, levelStr, found := strings.Cut(entry, "=")
+		if !found {
+			name, levelStr = "", name
+		}
+		name, levelStr = strings.TrimSpace(name), strings.TrimSpace(levelStr)
+
+		switch lv, ok := lookupLevel(levelStr); {
+		case found && name == "":
+			errs = append(errs, fmt.Errorf("logger: invalid level spec entry %q: missing logger name", entry))
+		case levelStr == "":
+			errs = append(errs, fmt.Errorf("logger: invalid level spec entry %q: missing level", entry))
+		case !ok:
+			errs = append(errs, fmt.Errorf("logger: invalid level spec entry %q: unknown level %q", entry, levelStr))
+		default:
+			levels[name] = lv
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return levels, nil
+}
@@ -1,186 +1,286 @@
 package logger
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ecromaneli-golang/console/logger/async"
 )
 
-// Level represents the severity of a log message.
-type Level uint8
-
-const (
-	// LevelOff disables all logging.
-	LevelOff Level = 0
-	// LevelFatal is for critical errors that cause application failure.
-	LevelFatal Level = 5
-	// LevelError is for runtime errors that don't cause application failure.
-	LevelError Level = 10
-	// LevelWarn is for potentially harmful situations.
-	LevelWarn Level = 15
-	// LevelInfo is for general information messages.
-	LevelInfo Level = 20
-	// LevelDebug is for detailed debugging information.
-	LevelDebug Level = 25
-	// LevelTrace is for the most fine-grained information.
-	LevelTrace Level = 30
-	// LevelAll enables all possible logging levels.
-	LevelAll Level = 255
-)
-
-// String returns the string representation of the log level.
-func (l *Level) String() string {
-	return stringByLevel[*l]
-}
-
-// LevelFromString converts a string representation to a Level.
-//
-// It returns the corresponding Level for the given string value.
-func LevelFromString(level string) Level {
-	return levelByStr[strings.ToUpper(level)]
-}
-
-var stringByLevel = map[Level]string{
-	LevelAll:   "ALL",
-	LevelTrace: "TRACE",
-	LevelDebug: "DEBUG",
-	LevelInfo:  "INFO",
-	LevelWarn:  "WARN",
-	LevelError: "ERROR",
-	LevelFatal: "FATAL",
-	LevelOff:   "OFF",
-}
-
-var levelByStr = map[string]Level{
-	"ALL":   LevelAll,
-	"TRACE": LevelTrace,
-	"DEBUG": LevelDebug,
-	"INFO":  LevelInfo,
-	"WARN":  LevelWarn,
-	"ERROR": LevelError,
-	"FATAL": LevelFatal,
-	"OFF":   LevelOff,
-}
-
-// LogDispatcher is a function type that handles formatting and writing log messages.
-type LogDispatcher func(w io.Writer, dateFormat string, name string, level Level, a ...any)
-
 // Logger provides methods for logging messages at different levels.
+//
+// A Logger is safe for concurrent use. Its settings can be changed while other
+// goroutines are logging, and each message uses the settings read when it is logged.
+//
+// The level, output, handler and date format that are not set on a logger are
+// inherited from its parent, so changing the parent also changes its children.
 type Logger struct {
 	name       string
-	dispatcher LogDispatcher
-	writer     io.Writer
-	logLevel   Level
-	dateFormat string
-}
-
+	parent     *Logger
+	byName     bool // The parent is the closest registered logger by name, for loggers created by New
+	resolved   atomic.Pointer[resolvedParent]
+	fields     []Field
+	handler    atomic.Pointer[Handler]
+	writer     atomic.Pointer[io.Writer]
+	logLevel   atomic.Uint32
+	dateFormat atomic.Pointer[string]
+	caller     atomic.Bool
+	callerSkip atomic.Int32
+	stackLevel atomic.Uint32
+	outputMu   sync.Mutex // Serialises output changes that depend on the current output
+}
+
+// levelUnset is stored as the level of loggers that inherit the level of their parent.
+const levelUnset = 1 << 8
+
+// callerDepth is the number of frames between runtime.Callers and the caller of a logging method.
+const callerDepth = 3 // runtime.Callers, dispatch and the logging method
+
+// The Default variables are the initial settings of the root logger, which every
+// logger inherits from unless they are overridden. Use the SetDefault functions
+// to change them once loggers have been created.
 var (
 	// DefaultDateFormat is the default format for timestamps in log messages.
 	DefaultDateFormat = "2006-01-02 15:04:05.000 Z07:00"
 	// DefaultWriter is the default output destination for log messages.
 	DefaultWriter io.Writer = os.Stdout
+	// DefaultHandler is the default handler used to format and write log messages.
+	DefaultHandler Handler = TextHandler{}
 	// DefaultDispatcher is the default function used to format and write log messages.
-	DefaultDispatcher = DefaultLogDispatcher
+	//
+	// Deprecated: Use DefaultHandler. DefaultDispatcher is kept in sync with the
+	// default handler, and a dispatcher assigned to it before loggers are created
+	// becomes the default handler.
+	DefaultDispatcher LogDispatcher = DefaultLogDispatcher
 	// DefaultLogLevel is the default level at which messages are logged.
 	DefaultLogLevel = LevelInfo
-	globalLogger    *Logger
+
+	defaultsMu       sync.RWMutex
+	globalLogger     *Logger
+	globalLoggerOnce sync.Once
 )
 
 // GetInstance returns the global logger instance, creating it if it doesn't exist.
 //
 // This provides a singleton pattern for accessing a shared logger.
 func GetInstance() *Logger {
-	if globalLogger == nil {
+	globalLoggerOnce.Do(func() {
 		globalLogger = New("")
-	}
+	})
 	return globalLogger
 }
 
-// SetDefaultDateFormat sets the default date format for new logger instances.
+// SetDefaultDateFormat sets the default date format of all loggers.
 //
 // The format should be compatible with Go's time.Format function.
+// Loggers with their own date format are not affected.
 func SetDefaultDateFormat(format string) error {
+	root := rootLogger()
+	defaultsMu.Lock()
 	DefaultDateFormat = format
+	root.SetDateFormat(format)
+	defaultsMu.Unlock()
 	return nil
 }
 
-// SetDefaultOutput sets the default writer for new logger instances.
+// SetDefaultOutput sets the default writer of all loggers.
 //
 // The writer is where log messages will be written.
+// Loggers with their own output are not affected.
 func SetDefaultOutput(writer io.Writer) {
+	root := rootLogger()
+	defaultsMu.Lock()
 	DefaultWriter = writer
+	root.SetOutput(writer)
+	defaultsMu.Unlock()
 }
 
-// SetDefaultLogDispatcher sets the default dispatcher function for new logger instances.
+// SetDefaultLogDispatcher sets the default dispatcher function of all loggers.
 //
 // The dispatcher controls how log messages are formatted and written.
+// Loggers with their own handler are not affected.
 func SetDefaultLogDispatcher(dispatcher LogDispatcher) {
-	DefaultDispatcher = dispatcher
+	SetDefaultHandler(dispatcher)
 }
 
-// SetDefaultLogLevel sets the default minimum level for new logger instances.
+// SetDefaultHandler sets the default handler of all loggers.
+//
+// The handler controls how log records are formatted and written.
+// Loggers with their own handler are not affected.
+func SetDefaultHandler(handler Handler) {
+	root := rootLogger()
+	defaultsMu.Lock()
+	DefaultHandler = handler
+	DefaultDispatcher = dispatcherOf(handler)
+	root.SetHandler(handler)
+	defaultsMu.Unlock()
+}
+
+// SetDefaultLogLevel sets the default minimum level of all loggers.
 //
 // Messages below this level will not be logged.
+// Loggers with their own level are not affected.
 func SetDefaultLogLevel(l Level) {
+	root := rootLogger()
+	defaultsMu.Lock()
 	DefaultLogLevel = l
+	root.SetLogLevel(l)
+	defaultsMu.Unlock()
 }
 
 // SetDefaultLogLevelStr sets the default minimum level using a string representation.
 //
 // It converts the string to the corresponding Level and sets it as the default.
+// An invalid level keeps the current default. Use SetDefaultLogLevelString to detect it.
 func SetDefaultLogLevelStr(levelStr string) {
-	DefaultLogLevel = LevelFromString(levelStr)
+	SetDefaultLogLevelString(levelStr)
 }
 
-// New creates a new logger with the given name and default settings.
+// SetDefaultLogLevelString sets the default minimum level using a string representation.
 //
-// The name is included in log messages to identify their source.
-func New(name string) *Logger {
-	return &Logger{
-		name:       name,
-		dispatcher: DefaultDispatcher,
-		writer:     DefaultWriter,
-		logLevel:   DefaultLogLevel,
-		dateFormat: DefaultDateFormat,
+// The string is parsed as by ParseLevel. It returns an error and keeps the
+// current default if the string is not a valid level.
+func SetDefaultLogLevelString(levelStr string) error {
+	lv, err := ParseLevel(levelStr)
+	if err != nil {
+		return err
 	}
+	SetDefaultLogLevel(lv)
+	return nil
+}
+
+// New creates a new logger with the given name.
+//
+// The name is included in log messages to identify their source. The logger
+// inherits the settings of the registered logger with the same name, returned
+// by Get, or of its closest registered ancestor, until they are set on it.
+// Loggers registered afterwards are taken into account, so configuring Get(name)
+// also configures the loggers created before by New(name).
+//
+// New does not register the logger, so it can be used for short-lived names,
+// such as one logger per request.
+func New(name string) *Logger {
+	l := &Logger{name: name, byName: true}
+	l.logLevel.Store(levelUnset)
+	return l
+}
+
+// newChild creates a logger that inherits the settings of parent.
+func newChild(parent *Logger, name string) *Logger {
+	l := &Logger{name: name, parent: parent}
+	l.logLevel.Store(levelUnset)
+	return l
+}
+
+// With returns a child logger that adds the given key/value pairs to every message.
+//
+// The keyvals alternate between string keys and values; Field values are also accepted.
+// The child inherits the level, output, handler and date format of this logger, and
+// starts with its other settings.
+func (l *Logger) With(keyvals ...any) *Logger {
+	child := newChild(l, l.name)
+	child.fields = append(l.fields[:len(l.fields):len(l.fields)], fieldsFromKeyvals(keyvals)...)
+	child.caller.Store(l.caller.Load())
+	child.callerSkip.Store(l.callerSkip.Load())
+	child.stackLevel.Store(l.stackLevel.Load())
+	return child
 }
 
 // SetLogLevel sets the minimum level at which messages will be logged.
 //
 // Messages below this level will not be logged.
 func (l *Logger) SetLogLevel(lv Level) {
-	l.logLevel = lv
+	l.logLevel.Store(uint32(lv))
 }
 
 // SetLogLevelStr sets the minimum log level using a string representation.
 //
 // It converts the string to the corresponding Level and sets it.
+// An invalid level keeps the current level. Use SetLogLevelString to detect it.
 func (l *Logger) SetLogLevelStr(levelStr string) {
-	l.logLevel = LevelFromString(levelStr)
+	l.SetLogLevelString(levelStr)
+}
+
+// SetLogLevelString sets the minimum log level using a string representation.
+//
+// The string is parsed as by ParseLevel. It returns an error and keeps the
+// current level if the string is not a valid level.
+func (l *Logger) SetLogLevelString(levelStr string) error {
+	lv, err := ParseLevel(levelStr)
+	if err != nil {
+		return err
+	}
+	l.SetLogLevel(lv)
+	return nil
 }
 
 // SetDateFormat sets the date format used in log messages.
 //
 // The format should be compatible with Go's time.Format function.
 func (l *Logger) SetDateFormat(format string) error {
-	l.dateFormat = format
+	l.dateFormat.Store(&format)
 	return nil
 }
 
+// SetReportCaller sets whether the call site of each message is captured.
+//
+// The call site is available to handlers through Record.PC and Record.Caller.
+// It is disabled by default, as capturing it has a cost on every message.
+func (l *Logger) SetReportCaller(enabled bool) {
+	l.caller.Store(enabled)
+}
+
+// SetCallerSkip sets the number of additional stack frames to skip when capturing the call site.
+//
+// Use it when the logging methods are called through helper functions, so the
+// call site is the caller of the helper. For example, 1 skips one helper.
+func (l *Logger) SetCallerSkip(skip int) {
+	l.callerSkip.Store(int32(skip))
+}
+
+// SetStacktraceLevel sets the level from which a stack trace is attached to messages.
+//
+// Messages at this level or more severe include the stack trace of the call site
+// in Record.Stack, preceded by the details of the error arguments that carry
+// their own stack trace. LevelOff, the default, disables stack traces.
+func (l *Logger) SetStacktraceLevel(lv Level) {
+	l.stackLevel.Store(uint32(lv))
+}
+
 // SetLogDispatcher sets the dispatcher function for this logger.
 //
 // The dispatcher controls how log messages are formatted and written.
 func (l *Logger) SetLogDispatcher(dispatcher LogDispatcher) {
-	l.dispatcher = dispatcher
+	l.SetHandler(dispatcher)
+}
+
+// SetHandler sets the handler for this logger.
+//
+// The handler controls how log records are formatted and written.
+func (l *Logger) SetHandler(handler Handler) {
+	l.handler.Store(&handler)
+}
+
+// SetSlogOutput forwards the log records to a slog.Handler.
+// Shorthand for l.SetHandler(logger.NewSlogForwarder(handler)).
+func (l *Logger) SetSlogOutput(handler slog.Handler) {
+	l.SetHandler(NewSlogForwarder(handler))
 }
 
 // SetOutput sets the output where log messages will be written.
+//
+// The writer must be safe for concurrent use if the logger is used by several goroutines.
 func (l *Logger) SetOutput(writer io.Writer) {
-	l.writer = writer
+	l.writer.Store(&writer)
 }
 
 // SetAsyncOutput sets the output to an asynchronous writer.
@@ -189,10 +289,13 @@ func (l *Logger) SetOutput(writer io.Writer) {
 // The bufferSize determines the number of pending writes that can be queued before blocking.
 // If the buffer is full, the log message will be written directly to the target writer.
 func (l *Logger) SetAsyncOutput(writer io.Writer, bufferSize int) {
-	if _, ok := l.writer.(*async.AsyncWriter); ok {
-		l.writer = writer
+	l.outputMu.Lock()
+	defer l.outputMu.Unlock()
+
+	if isAsync(l.Output()) {
+		l.SetOutput(writer)
 	} else {
-		l.writer = async.NewAsyncWriter(writer, bufferSize)
+		l.SetOutput(async.NewAsyncWriter(writer, bufferSize))
 	}
 }
 
@@ -202,24 +305,51 @@ func (l *Logger) SetAsyncOutput(writer io.Writer, bufferSize int) {
 // The bufferSize determines the number of pending writes that can be queued before blocking.
 // If the buffer is full, the log message will be written directly to the target writer.
 func (l *Logger) SetAsync(bufferSize int) {
-	if _, ok := l.writer.(*async.AsyncWriter); !ok {
-		l.writer = async.NewAsyncWriter(l.writer, bufferSize)
+	l.outputMu.Lock()
+	defer l.outputMu.Unlock()
+
+	if writer := l.Output(); !isAsync(writer) {
+		l.SetOutput(async.NewAsyncWriter(writer, bufferSize))
 	}
 }
 
 // SetSync sets the current output to synchronous mode.
+//
+// The target writer replaces the output before the asynchronous writer is closed,
+// so messages logged during the switch are not rejected by the closing writer.
+// An asynchronous output inherited from the parent is left unchanged.
 func (l *Logger) SetSync() {
-	if asyncWriter, ok := l.writer.(*async.AsyncWriter); ok {
-		asyncWriter.Flush()
-		l.writer = asyncWriter.Target()
+	l.outputMu.Lock()
+	defer l.outputMu.Unlock()
+
+	writer := l.writer.Load()
+	if writer == nil {
+		return
+	}
+	if asyncWriter, ok := (*writer).(*async.AsyncWriter); ok {
+		l.SetOutput(asyncWriter.Target())
+		asyncWriter.Close()
 	}
 }
 
+// isAsync reports whether the writer is an asynchronous writer.
+func isAsync(writer io.Writer) bool {
+	_, ok := writer.(*async.AsyncWriter)
+	return ok
+}
+
 // IsEnabled returns true if the given level is enabled for logging.
 //
-// A level is enabled if it is greater than or equal to the logger's level.
+// A level is enabled if it is greater than or equal to the logger's level, and
+// if the handler enables it when it is an EnabledHandler.
 func (l *Logger) IsEnabled(lv Level) bool {
-	return l.logLevel >= lv
+	if l.LogLevel() < lv {
+		return false
+	}
+	if handler, ok := l.Handler().(EnabledHandler); ok {
+		return handler.Enabled(lv)
+	}
+	return true
 }
 
 // IsFatalEnabled returns true if fatal level messages will be logged.
@@ -266,64 +396,278 @@ func (l *Logger) IsTraceEnabled() bool {
 
 // Log logs a message at the specified level.
 //
-// If the level is enabled, the message is passed to the dispatcher.
+// If the level is enabled, the message is passed to the handler.
 func (l *Logger) Log(lv Level, a ...any) {
 	if l.IsEnabled(lv) {
-		l.dispatcher(l.writer, l.dateFormat, l.name, lv, a...)
+		l.dispatch(lv, "", a, nil)
 	}
 }
 
+// Logf logs a message formatted with a printf-style template at the specified level.
+//
+// The message is only formatted if the level is enabled. The handler receives
+// the template and the arguments separately.
+func (l *Logger) Logf(lv Level, format string, a ...any) {
+	if l.IsEnabled(lv) {
+		l.dispatch(lv, format, a, nil)
+	}
+}
+
+// Logw logs a message with key/value pairs at the specified level.
+//
+// The keyvals alternate between string keys and values; Field values are also accepted.
+func (l *Logger) Logw(lv Level, msg string, keyvals ...any) {
+	if l.IsEnabled(lv) {
+		l.dispatch(lv, "", []any{msg}, keyvals)
+	}
+}
+
+// dispatch builds a record from the template, arguments and key/value pairs and passes it to the handler.
+//
+// The record fields are the fields bound to the logger followed by the key/value pairs.
+// The record is taken from a pool, and copies the arguments so they do not escape.
+func (l *Logger) dispatch(lv Level, format string, a []any, keyvals []any) {
+	r := getRecord()
+	defer putRecord(r)
+
+	r.Time = time.Now()
+	r.Level = lv
+	r.Format = format
+	if l.caller.Load() {
+		var pcs [1]uintptr
+		runtime.Callers(callerDepth+int(l.callerSkip.Load()), pcs[:])
+		r.PC = pcs[0]
+	}
+	r.Args = append(r.Args, a...)
+	r.Fields = appendKeyvals(append(r.Fields, l.fields...), keyvals)
+	if stackLevel := l.StacktraceLevel(); stackLevel != LevelOff && lv <= stackLevel {
+		r.Stack = captureStack(callerDepth+int(l.callerSkip.Load()), r.Args, r.Fields)
+	}
+	l.handle(r)
+}
+
+// handle completes the record with the logger settings and passes it to the handler.
+func (l *Logger) handle(r *Record) {
+	r.Name = l.name
+	r.DateFormat = l.DateFormat()
+	l.Handler().Handle(l.Output(), r)
+}
+
 // Fatal logs a message at the fatal level.
 //
 // This should be used for critical errors that cause application failure.
+// The process terminates afterwards if enabled by SetExitOnFatal.
 func (l *Logger) Fatal(a ...any) {
-	l.Log(LevelFatal, a...)
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, "", a, nil)
+	}
+	exitIfFatal()
 }
 
 // Error logs a message at the error level.
 //
 // This should be used for runtime errors that don't cause application failure.
 func (l *Logger) Error(a ...any) {
-	l.Log(LevelError, a...)
+	if l.IsEnabled(LevelError) {
+		l.dispatch(LevelError, "", a, nil)
+	}
 }
 
 // Warn logs a message at the warning level.
 //
 // This should be used for potentially harmful situations.
 func (l *Logger) Warn(a ...any) {
-	l.Log(LevelWarn, a...)
+	if l.IsEnabled(LevelWarn) {
+		l.dispatch(LevelWarn, "", a, nil)
+	}
 }
 
 // Info logs a message at the info level.
 //
 // This should be used for general information messages.
 func (l *Logger) Info(a ...any) {
-	l.Log(LevelInfo, a...)
+	if l.IsEnabled(LevelInfo) {
+		l.dispatch(LevelInfo, "", a, nil)
+	}
 }
 
 // Debug logs a message at the debug level.
 //
 // This should be used for detailed debugging information.
 func (l *Logger) Debug(a ...any) {
-	l.Log(LevelDebug, a...)
+	if l.IsEnabled(LevelDebug) {
+		l.dispatch(LevelDebug, "", a, nil)
+	}
 }
 
 // Trace logs a message at the trace level.
 //
 // This should be used for the most fine-grained information.
 func (l *Logger) Trace(a ...any) {
-	l.Log(LevelTrace, a...)
+	if l.IsEnabled(LevelTrace) {
+		l.dispatch(LevelTrace, "", a, nil)
+	}
+}
+
+// Fatalf logs a message formatted with a printf-style template at the fatal level.
+//
+// The process terminates afterwards if enabled by SetExitOnFatal.
+func (l *Logger) Fatalf(format string, a ...any) {
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, format, a, nil)
+	}
+	exitIfFatal()
+}
+
+// Errorf logs a message formatted with a printf-style template at the error level.
+func (l *Logger) Errorf(format string, a ...any) {
+	if l.IsEnabled(LevelError) {
+		l.dispatch(LevelError, format, a, nil)
+	}
+}
+
+// Warnf logs a message formatted with a printf-style template at the warning level.
+func (l *Logger) Warnf(format string, a ...any) {
+	if l.IsEnabled(LevelWarn) {
+		l.dispatch(LevelWarn, format, a, nil)
+	}
+}
+
+// Infof logs a message formatted with a printf-style template at the info level.
+func (l *Logger) Infof(format string, a ...any) {
+	if l.IsEnabled(LevelInfo) {
+		l.dispatch(LevelInfo, format, a, nil)
+	}
+}
+
+// Debugf logs a message formatted with a printf-style template at the debug level.
+func (l *Logger) Debugf(format string, a ...any) {
+	if l.IsEnabled(LevelDebug) {
+		l.dispatch(LevelDebug, format, a, nil)
+	}
+}
+
+// Tracef logs a message formatted with a printf-style template at the trace level.
+func (l *Logger) Tracef(format string, a ...any) {
+	if l.IsEnabled(LevelTrace) {
+		l.dispatch(LevelTrace, format, a, nil)
+	}
+}
+
+// Fatalw logs a message with key/value pairs at the fatal level.
+//
+// The process terminates afterwards if enabled by SetExitOnFatal.
+func (l *Logger) Fatalw(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, "", []any{msg}, keyvals)
+	}
+	exitIfFatal()
+}
+
+// Errorw logs a message with key/value pairs at the error level.
+func (l *Logger) Errorw(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelError) {
+		l.dispatch(LevelError, "", []any{msg}, keyvals)
+	}
+}
+
+// Warnw logs a message with key/value pairs at the warning level.
+func (l *Logger) Warnw(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelWarn) {
+		l.dispatch(LevelWarn, "", []any{msg}, keyvals)
+	}
+}
+
+// Infow logs a message with key/value pairs at the info level.
+func (l *Logger) Infow(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelInfo) {
+		l.dispatch(LevelInfo, "", []any{msg}, keyvals)
+	}
+}
+
+// Debugw logs a message with key/value pairs at the debug level.
+func (l *Logger) Debugw(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelDebug) {
+		l.dispatch(LevelDebug, "", []any{msg}, keyvals)
+	}
+}
+
+// Tracew logs a message with key/value pairs at the trace level.
+func (l *Logger) Tracew(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelTrace) {
+		l.dispatch(LevelTrace, "", []any{msg}, keyvals)
+	}
+}
+
+// Panic logs a message at the fatal level and then panics with the message.
+//
+// The panic happens even if the fatal level is disabled.
+func (l *Logger) Panic(a ...any) {
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, "", a, nil)
+	}
+	panic(strings.TrimSuffix(fmt.Sprintln(a...), "\n"))
+}
+
+// Panicf logs a message formatted with a printf-style template at the fatal
+// level and then panics with the message.
+//
+// The panic happens even if the fatal level is disabled.
+func (l *Logger) Panicf(format string, a ...any) {
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, format, a, nil)
+	}
+	panic(fmt.Sprintf(format, a...))
+}
+
+// Panicw logs a message with key/value pairs at the fatal level and then panics with the message.
+//
+// The panic happens even if the fatal level is disabled.
+func (l *Logger) Panicw(msg string, keyvals ...any) {
+	if l.IsEnabled(LevelFatal) {
+		l.dispatch(LevelFatal, "", []any{msg}, keyvals)
+	}
+	panic(msg)
 }
 
 // Flush waits for all pending writes to complete.
 // It is only necessary if the logger is using an asynchronous writer.
 // Shorthand for logger.Output().Flush().
+//
+// The handler is also flushed if it has a Flush method, as TeeHandler does.
+// The logger keeps logging normally after flushing.
 func (l *Logger) Flush() {
-	if asyncWriter, ok := l.writer.(*async.AsyncWriter); ok {
+	if handler, ok := l.Handler().(interface{ Flush() }); ok {
+		handler.Flush()
+	}
+	if asyncWriter, ok := l.Output().(*async.AsyncWriter); ok {
 		asyncWriter.Flush()
 	}
 }
 
+// Close waits for all pending writes to complete and closes the asynchronous writer.
+// It is only necessary if the logger is using an asynchronous writer.
+// Shorthand for logger.Output().Close().
+//
+// The handler is also closed if it has a Close method, as TeeHandler does.
+// Only the output and the handler set on this logger are closed: those inherited
+// from its parent are shared with other loggers and are left open.
+// Messages logged afterwards are discarded until a new output is set.
+func (l *Logger) Close() error {
+	var err error
+	if handler := l.handler.Load(); handler != nil {
+		if closer, ok := (*handler).(io.Closer); ok {
+			err = closer.Close()
+		}
+	}
+	if writer := l.writer.Load(); writer != nil {
+		if asyncWriter, ok := (*writer).(*async.AsyncWriter); ok {
+			return errors.Join(err, asyncWriter.Close())
+		}
+	}
+	return err
+}
+
 // Name returns the name of the logger.
 //
 // The name is included in log messages to identify their source.
@@ -331,82 +675,101 @@ func (l *Logger) Name() string {
 	return l.name
 }
 
+// Fields returns the key/value pairs bound to the logger by With.
+func (l *Logger) Fields() []Field {
+	return slices.Clone(l.fields)
+}
+
 // Dispatcher returns the current log dispatcher function.
 //
-// The dispatcher controls how log messages are formatted and written.
+// If the handler is not a LogDispatcher, the returned function forwards to it.
 func (l *Logger) Dispatcher() LogDispatcher {
-	return l.dispatcher
+	return dispatcherOf(l.Handler())
+}
+
+// Handler returns the current handler.
+//
+// The handler controls how log records are formatted and written.
+func (l *Logger) Handler() Handler {
+	for p := l.Parent(); p != nil; l, p = p, p.Parent() {
+		if handler := l.handler.Load(); handler != nil {
+			return *handler
+		}
+	}
+	return *l.handler.Load()
 }
 
 // Output returns the current writer where log messages are written.
 //
 // This is the destination for all log messages.
 func (l *Logger) Output() io.Writer {
-	return l.writer
+	for p := l.Parent(); p != nil; l, p = p, p.Parent() {
+		if writer := l.writer.Load(); writer != nil {
+			return *writer
+		}
+	}
+	return *l.writer.Load()
 }
 
 // LogLevel returns the minimum level at which messages will be logged.
 //
 // Messages below this level will not be logged.
 func (l *Logger) LogLevel() Level {
-	return l.logLevel
+	for p := l.Parent(); p != nil; l, p = p, p.Parent() {
+		if lv := l.logLevel.Load(); lv != levelUnset {
+			return Level(lv)
+		}
+	}
+	return Level(l.logLevel.Load())
+}
+
+// ReportCaller returns true if the call site of each message is captured.
+func (l *Logger) ReportCaller() bool {
+	return l.caller.Load()
+}
+
+// CallerSkip returns the number of additional stack frames skipped when capturing the call site.
+func (l *Logger) CallerSkip() int {
+	return int(l.callerSkip.Load())
+}
+
+// StacktraceLevel returns the level from which a stack trace is attached to messages.
+func (l *Logger) StacktraceLevel() Level {
+	return Level(l.stackLevel.Load())
 }
 
 // DateFormat returns the date format used in log messages.
 //
 // The format is compatible with Go's time.Format function.
 func (l *Logger) DateFormat() string {
-	return l.dateFormat
+	for p := l.Parent(); p != nil; l, p = p, p.Parent() {
+		if format := l.dateFormat.Load(); format != nil {
+			return *format
+		}
+	}
+	return *l.dateFormat.Load()
 }
 
-// DefaultLogDispatcher is the default function for formatting and writing log messages.
+// Parent returns the logger whose settings are inherited by this logger.
 //
-// It formats the message with a timestamp, log level, name, and the message content.
-func DefaultLogDispatcher(w io.Writer, dateFormat string, name string, l Level, a ...any) {
-	estSize := 6
-
-	if dateFormat != "" {
-		dateFormat = time.Now().Format(dateFormat)
-		estSize += len(dateFormat) + 3 // 3 for " - "
+// It returns nil for the root logger.
+func (l *Logger) Parent() *Logger {
+	if !l.byName {
+		return l.parent
 	}
 
-	if name != "" {
-		estSize += len(name) + 2 // 2 for " :"
+	// Resolve the parent again only after new loggers have been registered
+	gen := registryGen.Load()
+	if resolved := l.resolved.Load(); resolved != nil && resolved.gen == gen {
+		return resolved.parent
 	}
+	resolved := &resolvedParent{gen: gen, parent: closestRegistered(l.name)}
+	l.resolved.Store(resolved)
+	return resolved.parent
+}
 
-	message := fmt.Sprintln(a...)
-
-	estSize += len(message)
-
-	var builder strings.Builder
-	builder.Grow(estSize)
-
-	// Add the timestamp if a date format is provided
-	if dateFormat != "" {
-		builder.WriteString(dateFormat)
-		builder.WriteString(" - ")
-	}
-
-	// Add the log level
-	levelStr := l.String()
-	builder.WriteString(levelStr)
-	if len(levelStr) == 4 {
-		builder.WriteByte(' ')
-	}
-
-	// Add the logger name if provided
-	if name != "" {
-		builder.WriteByte(' ')
-		builder.WriteString(name)
-		builder.WriteByte(':')
-	}
-
-	// Add a space before the message
-	builder.WriteByte(' ')
-
-	// Add the log message
-	builder.WriteString(message)
-
-	// Write the final message to the writer
-	fmt.Fprint(w, builder.String())
+// resolvedParent is the parent of a logger created by New, as resolved at a registry generation.
+type resolvedParent struct {
+	gen    uint64
+	parent *Logger
 }
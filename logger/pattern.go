@@ -0,0 +1,404 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// patternConverter identifies what a conversion of a pattern layout writes.
+type patternConverter uint8
+
+const (
+	patternLiteral patternConverter = iota
+	patternDate
+	patternLevel
+	patternLogger
+	patternMessage
+	patternNewline
+	patternCaller
+	patternFile
+	patternLine
+	patternFunc
+	patternFields
+	patternGoroutine
+	patternStack
+)
+
+// patternConverters maps the conversion words to their converters.
+var patternConverters = map[string]patternConverter{
+	"d":    patternDate,
+	"date": patternDate,
+
+	"p":     patternLevel,
+	"le":    patternLevel,
+	"level": patternLevel,
+
+	"c":      patternLogger,
+	"lo":     patternLogger,
+	"logger": patternLogger,
+
+	"m":       patternMessage,
+	"msg":     patternMessage,
+	"message": patternMessage,
+
+	"n": patternNewline,
+
+	"caller": patternCaller,
+
+	"F":    patternFile,
+	"file": patternFile,
+
+	"L":    patternLine,
+	"line": patternLine,
+
+	"M":      patternFunc,
+	"func":   patternFunc,
+	"method": patternFunc,
+
+	"X":      patternFields,
+	"kv":     patternFields,
+	"fields": patternFields,
+
+	"gid":       patternGoroutine,
+	"goroutine": patternGoroutine,
+
+	"stack": patternStack,
+}
+
+// patternPart is a literal text or a conversion of a pattern layout.
+type patternPart struct {
+	converter patternConverter
+	text      string // The literal text, or the option between braces
+	min       int    // Minimum width, or zero
+	max       int    // Maximum width, or zero
+	left      bool   // Padding on the right, for the "-" modifier
+	truncEnd  bool   // Truncation of the end, for the ".-" modifier
+}
+
+// PatternHandler formats records with a pattern layout, such as
+// "%d{15:04:05} [%-5level] %logger{20}: %msg%n".
+//
+// The layout is literal text with conversions, written as a percent sign
+// followed by an optional format modifier, a conversion word and an optional
+// option between braces. The conversion words are:
+//
+//	%d, %date          the time, formatted with the option or with the date format of the logger
+//	%p, %le, %level    the level name
+//	%c, %lo, %logger   the logger name, abbreviated to the length in the option
+//	%m, %msg, %message the message
+//	%X, %kv, %fields   the fields as key=value pairs, or the value of the field in the option
+//	%caller            the call site, as "dir/file.go:line"
+//	%F, %file          the file name of the call site
+//	%L, %line          the line number of the call site
+//	%M, %func, %method the function name of the call site
+//	%gid, %goroutine   the id of the logging goroutine
+//	%stack             the stack trace, with every line indented by a tab
+//	%n                 a newline
+//	%%                 a percent sign
+//
+// The call site conversions are empty unless the logger reports the caller.
+// The logger name is abbreviated by shortening its first dot-separated elements
+// to their initial, and the option 0 keeps only its last element.
+//
+// The format modifier pads or truncates the conversion: %5level pads the level
+// on the left to 5 characters, %-5level pads it on the right, %.3level keeps its
+// last 3 characters and %.-3level keeps its first 3 characters.
+type PatternHandler struct {
+	layout string
+	parts  []patternPart
+}
+
+// NewPatternHandler compiles the layout into a PatternHandler.
+//
+// It returns an error if the layout has an unknown conversion word or an unclosed option.
+func NewPatternHandler(layout string) (*PatternHandler, error) {
+	h := &PatternHandler{layout: layout}
+	var literal []byte
+
+	for i := 0; i < len(layout); {
+		if layout[i] != '%' {
+			literal = append(literal, layout[i])
+			i++
+			continue
+		}
+		if i+1 < len(layout) && layout[i+1] == '%' {
+			literal = append(literal, '%')
+			i += 2
+			continue
+		}
+
+		part, n, err := parsePatternConversion(layout[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("logger: invalid pattern %q at offset %d: %w", layout, i, err)
+		}
+		if len(literal) > 0 {
+			h.parts = append(h.parts, patternPart{converter: patternLiteral, text: string(literal)})
+			literal = literal[:0]
+		}
+		h.parts = append(h.parts, part)
+		i += 1 + n
+	}
+
+	if len(literal) > 0 {
+		h.parts = append(h.parts, patternPart{converter: patternLiteral, text: string(literal)})
+	}
+	return h, nil
+}
+
+// NewPatternLogDispatcher compiles the layout into a dispatcher function.
+//
+// It is the LogDispatcher form of NewPatternHandler.
+func NewPatternLogDispatcher(layout string) (LogDispatcher, error) {
+	h, err := NewPatternHandler(layout)
+	if err != nil {
+		return nil, err
+	}
+	return func(w io.Writer, dateFormat string, name string, l Level, a ...any) {
+		handleDispatch(h, w, dateFormat, name, l, a)
+	}, nil
+}
+
+// parsePatternConversion parses a conversion that follows a percent sign,
+// returning it with the number of bytes it takes.
+func parsePatternConversion(s string) (patternPart, int, error) {
+	var part patternPart
+	i := 0
+
+	// Parse the format modifier
+	if i < len(s) && s[i] == '-' {
+		part.left = true
+		i++
+	}
+	part.min, i = parsePatternInt(s, i)
+	if i < len(s) && s[i] == '.' {
+		i++
+		if i < len(s) && s[i] == '-' {
+			part.truncEnd = true
+			i++
+		}
+		start := i
+		if part.max, i = parsePatternInt(s, i); i == start || part.max == 0 {
+			return part, 0, errors.New("missing maximum width")
+		}
+	}
+
+	// Parse the conversion word
+	start := i
+	for i < len(s) && ('a' <= s[i] && s[i] <= 'z' || 'A' <= s[i] && s[i] <= 'Z') {
+		i++
+	}
+	word := s[start:i]
+	converter, ok := patternConverters[word]
+	if !ok {
+		if word == "" {
+			return part, 0, errors.New("missing conversion word")
+		}
+		return part, 0, fmt.Errorf("unknown conversion word %q", word)
+	}
+	part.converter = converter
+
+	// Parse the option
+	if i < len(s) && s[i] == '{' {
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			return part, 0, fmt.Errorf("unclosed option of %%%s", word)
+		}
+		part.text = s[i+1 : i+end]
+		i += end + 1
+
+		if converter == patternLogger {
+			if _, err := strconv.Atoi(part.text); err != nil {
+				return part, 0, fmt.Errorf("invalid length %q of %%%s", part.text, word)
+			}
+		}
+	}
+
+	return part, i, nil
+}
+
+// parsePatternInt parses the decimal number at the offset, returning it with the offset after it.
+func parsePatternInt(s string, i int) (int, int) {
+	n := 0
+	for ; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n, i
+}
+
+// Layout returns the pattern layout of the handler.
+func (h *PatternHandler) Layout() string {
+	return h.layout
+}
+
+// Handle formats the record and writes it to w with a single Write call.
+func (h *PatternHandler) Handle(w io.Writer, r *Record) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	for i := range h.parts {
+		part := &h.parts[i]
+		start := len(*buf)
+		*buf = appendPatternPart(*buf, r, part)
+		if part.min > 0 || part.max > 0 {
+			*buf = formatPatternPart(*buf, start, part)
+		}
+	}
+
+	w.Write(*buf)
+}
+
+// appendPatternPart appends the conversion of the record by the part.
+func appendPatternPart(buf []byte, r *Record, part *patternPart) []byte {
+	switch part.converter {
+	case patternLiteral:
+		return append(buf, part.text...)
+	case patternDate:
+		if part.text != "" {
+			return r.Time.AppendFormat(buf, part.text)
+		}
+		if r.DateFormat != "" {
+			return r.Time.AppendFormat(buf, r.DateFormat)
+		}
+		return buf
+	case patternLevel:
+		return append(buf, r.Level.String()...)
+	case patternLogger:
+		if part.text == "" {
+			return append(buf, r.Name...)
+		}
+		n, _ := strconv.Atoi(part.text)
+		return appendAbbreviatedName(buf, r.Name, n)
+	case patternMessage:
+		return r.AppendMessage(buf)
+	case patternNewline:
+		return append(buf, '\n')
+	case patternCaller:
+		return r.AppendCaller(buf)
+	case patternFile:
+		if frame := r.Caller(); frame.File != "" {
+			return append(buf, filepath.Base(frame.File)...)
+		}
+		return buf
+	case patternLine:
+		if frame := r.Caller(); frame.Line != 0 {
+			return strconv.AppendInt(buf, int64(frame.Line), 10)
+		}
+		return buf
+	case patternFunc:
+		return append(buf, r.Caller().Function...)
+	case patternFields:
+		return appendPatternFields(buf, r.Fields, part.text)
+	case patternGoroutine:
+		return appendGoroutineID(buf)
+	case patternStack:
+		return appendIndented(buf, r.Stack, "\t")
+	}
+	return buf
+}
+
+// formatPatternPart pads or truncates the conversion that starts at the offset
+// of the buffer, according to the format modifier of the part.
+func formatPatternPart(buf []byte, start int, part *patternPart) []byte {
+	n := utf8.RuneCount(buf[start:])
+
+	if part.max > 0 && n > part.max {
+		text := buf[start:]
+		if part.truncEnd {
+			for range part.max {
+				_, size := utf8.DecodeRune(text)
+				text = text[size:]
+			}
+			return buf[:len(buf)-len(text)]
+		}
+		for range n - part.max {
+			_, size := utf8.DecodeRune(text)
+			text = text[size:]
+		}
+		return append(buf[:start], text...)
+	}
+
+	if n >= part.min {
+		return buf
+	}
+	padding := part.min - n
+	if part.left {
+		for range padding {
+			buf = append(buf, ' ')
+		}
+		return buf
+	}
+
+	for range padding {
+		buf = append(buf, ' ')
+	}
+	copy(buf[start+padding:], buf[start:len(buf)-padding])
+	for i := start; i < start+padding; i++ {
+		buf[i] = ' '
+	}
+	return buf
+}
+
+// appendAbbreviatedName appends the logger name, shortening its first
+// dot-separated elements to their initial until it fits the length.
+// The last element is never shortened, and a length of 0 keeps only the last element.
+func appendAbbreviatedName(buf []byte, name string, length int) []byte {
+	if length == 0 {
+		return append(buf, name[strings.LastIndexByte(name, '.')+1:]...)
+	}
+
+	excess := len(name) - length
+	for excess > 0 {
+		i := strings.IndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		if i > 1 {
+			buf = append(buf, name[0])
+			excess -= i - 1
+		} else {
+			buf = append(buf, name[:i]...)
+		}
+		buf = append(buf, '.')
+		name = name[i+1:]
+	}
+	return append(buf, name...)
+}
+
+// appendPatternFields appends the fields as space-separated key=value pairs,
+// or the value of the field with the given key.
+func appendPatternFields(buf []byte, fields []Field, key string) []byte {
+	if key != "" {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if fields[i].Key == key {
+				return appendValue(buf, fields[i].Value)
+			}
+		}
+		return buf
+	}
+
+	for i, field := range fields {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendField(buf, field)
+	}
+	return buf
+}
+
+// appendGoroutineID appends the id of the current goroutine, read from the header of its stack trace.
+func appendGoroutineID(buf []byte) []byte {
+	var stack [64]byte
+	header := stack[:runtime.Stack(stack[:], false)]
+	header, _ = bytes.CutPrefix(header, []byte("goroutine "))
+	if i := bytes.IndexByte(header, ' '); i > 0 {
+		return append(buf, header[:i]...)
+	}
+	return buf
+}
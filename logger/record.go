@@ -0,0 +1,235 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record holds everything known about a single log message.
+//
+// Handlers must not modify a record or retain it after Handle returns,
+// as records are reused. Use Clone to keep a copy.
+type Record struct {
+	// Time is when the message was logged, captured at the call site.
+	Time time.Time
+	// Level is the severity of the message.
+	Level Level
+	// Name is the name of the logger.
+	Name string
+	// DateFormat is the date format configured on the logger.
+	DateFormat string
+	// Format is the printf-style template of the message, or empty if the
+	// arguments are not formatted with a template.
+	Format string
+	// Args are the message arguments, without the fields.
+	Args []any
+	// Fields are the fields bound to the logger followed by the message fields.
+	Fields []Field
+	// PC is the program counter of the call site, or zero if it was not captured.
+	PC uintptr
+	// Stack is the stack trace attached to the message, or empty if none was captured.
+	Stack string
+}
+
+// Caller returns the frame of the call site, or a zero frame if it was not captured.
+func (r *Record) Caller() runtime.Frame {
+	if r.PC == 0 {
+		return runtime.Frame{}
+	}
+
+	frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+	return frame
+}
+
+// AppendCaller appends the call site as "dir/file.go:line", with only the last
+// directory of the file path, to buf and returns the extended buffer.
+// Nothing is appended if the call site was not captured.
+func (r *Record) AppendCaller(buf []byte) []byte {
+	frame := r.Caller()
+	if frame.File == "" {
+		return buf
+	}
+
+	file := frame.File
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+
+	buf = append(buf, file...)
+	buf = append(buf, ':')
+	return strconv.AppendInt(buf, int64(frame.Line), 10)
+}
+
+// Clone returns a copy of the record that does not share memory with it.
+func (r *Record) Clone() *Record {
+	c := *r
+	c.Args = slices.Clone(r.Args)
+	c.Fields = slices.Clone(r.Fields)
+	return &c
+}
+
+// Message returns the formatted message.
+//
+// The arguments are formatted with the Format template as by fmt.Sprintf, or
+// as by fmt.Sprintln, without the trailing newline, if there is no template.
+func (r *Record) Message() string {
+	return string(r.AppendMessage(nil))
+}
+
+// AppendMessage appends the formatted message to buf and returns the extended buffer.
+//
+// The message is formatted as described by Message.
+func (r *Record) AppendMessage(buf []byte) []byte {
+	if r.Format != "" {
+		return fmt.Appendf(buf, r.Format, r.Args...)
+	}
+
+	for i, arg := range r.Args {
+		if i > 0 {
+			buf = append(buf, ' ')
+		}
+		buf = appendValue(buf, arg)
+	}
+	return buf
+}
+
+// recordPool recycles the records created by the loggers.
+var recordPool = sync.Pool{
+	New: func() any { return new(Record) },
+}
+
+// getRecord returns an empty record from the pool.
+func getRecord() *Record {
+	return recordPool.Get().(*Record)
+}
+
+// putRecord clears the record, keeping the capacity of its slices, and returns it to the pool.
+func putRecord(r *Record) {
+	// Records that grew too much are left to the garbage collector
+	if cap(r.Args) > 64 || cap(r.Fields) > 64 {
+		return
+	}
+
+	clear(r.Args)
+	clear(r.Fields)
+	*r = Record{Args: r.Args[:0], Fields: r.Fields[:0]}
+	recordPool.Put(r)
+}
+
+// Handler formats a log record and writes it to the logger output.
+//
+// Handle may be called concurrently by the goroutines sharing a logger.
+type Handler interface {
+	Handle(w io.Writer, r *Record)
+}
+
+// HandlerFunc adapts an ordinary function to the Handler interface.
+type HandlerFunc func(w io.Writer, r *Record)
+
+// Handle calls f(w, r).
+func (f HandlerFunc) Handle(w io.Writer, r *Record) {
+	f(w, r)
+}
+
+// LogDispatcher is a function type that handles formatting and writing log messages.
+//
+// It implements Handler, receiving the record arguments followed by its fields as
+// Field values, so existing dispatchers keep working wherever a Handler is expected.
+// Records with a Format template are passed as their formatted message, and the
+// call site and the stack trace, if captured, are passed as fields with the
+// "caller" and "stack" keys. The call site prints as "dir/file.go:line", and
+// the dispatcher forms of the handlers, such as DefaultLogDispatcher, render
+// both as their handler does.
+type LogDispatcher func(w io.Writer, dateFormat string, name string, level Level, a ...any)
+
+// Handle implements Handler by passing the record to the dispatcher.
+//
+// The dispatcher receives its own copy of the arguments, as it may keep them
+// while the record is reused.
+func (d LogDispatcher) Handle(w io.Writer, r *Record) {
+	a := make([]any, 0, len(r.Args)+len(r.Fields)+2)
+	if r.Format != "" {
+		a = append(a, r.Message())
+	} else {
+		a = append(a, r.Args...)
+	}
+	if r.PC != 0 {
+		a = append(a, Field{Key: "caller", Value: callSite(r.PC)})
+	}
+	if r.Stack != "" {
+		a = append(a, Field{Key: "stack", Value: stackTrace(r.Stack)})
+	}
+	for _, field := range r.Fields {
+		a = append(a, field)
+	}
+
+	d(w, r.DateFormat, r.Name, r.Level, a...)
+}
+
+// callSite is the value of the "caller" field passed to dispatchers.
+// It prints as "dir/file.go:line", and dispatchers formed from handlers turn it
+// back into the call site of the record.
+type callSite uintptr
+
+// String returns the call site as "dir/file.go:line".
+func (pc callSite) String() string {
+	r := Record{PC: uintptr(pc)}
+	return string(r.AppendCaller(nil))
+}
+
+// stackTrace is the value of the "stack" field passed to dispatchers.
+// Dispatchers formed from handlers turn it back into the stack trace of the record.
+type stackTrace string
+
+// String returns the stack trace.
+func (s stackTrace) String() string {
+	return string(s)
+}
+
+// dispatcherOf returns a LogDispatcher that forwards to the given handler.
+func dispatcherOf(h Handler) LogDispatcher {
+	if d, ok := h.(LogDispatcher); ok {
+		return d
+	}
+
+	return func(w io.Writer, dateFormat string, name string, level Level, a ...any) {
+		handleDispatch(h, w, dateFormat, name, level, a)
+	}
+}
+
+// handleDispatch passes the arguments of a dispatcher call to the handler as a
+// record, taking the time as now.
+func handleDispatch(h Handler, w io.Writer, dateFormat string, name string, level Level, a []any) {
+	r := getRecord()
+	defer putRecord(r)
+
+	r.Time = time.Now()
+	r.Level = level
+	r.Name = name
+	r.DateFormat = dateFormat
+	for _, arg := range a {
+		if field, ok := arg.(Field); ok {
+			switch v := field.Value.(type) {
+			case callSite:
+				r.PC = uintptr(v)
+				continue
+			case stackTrace:
+				r.Stack = string(v)
+				continue
+			}
+			r.Fields = append(r.Fields, field)
+		} else {
+			r.Args = append(r.Args, arg)
+		}
+	}
+
+	h.Handle(w, r)
+}
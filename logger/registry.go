@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	registryMu  sync.Mutex
+	registry    = map[string]*Logger{}
+	registryGen atomic.Uint64 // Incremented when a logger is registered
+)
+
+// Get returns the registered logger with the given name, creating it if it doesn't exist.
+//
+// Names are dot-separated paths, and the parent of a registered logger is the
+// registered logger named by its path without the last element: the parent of
+// "db.pool" is "db", and the parent of "db" is the root logger, returned by Get("").
+// A registered logger inherits the settings of its parent until they are set on it,
+// so configuring "db" also configures "db.pool" and the loggers created by New("db.pool").
+func Get(name string) *Logger {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return getLocked(name)
+}
+
+// getLocked returns the registered logger with the given name, registering it and its ancestors if needed.
+// The caller must hold registryMu.
+func getLocked(name string) *Logger {
+	if l, ok := registry[name]; ok {
+		return l
+	}
+
+	var l *Logger
+	if name == "" {
+		l = rootLogger()
+	} else {
+		l = newChild(getLocked(parentName(name)), name)
+	}
+	registry[name] = l
+	registryGen.Add(1)
+	return l
+}
+
+// closestRegistered returns the registered logger with the given name, or with
+// the longest name among its ancestors, falling back to the root logger.
+func closestRegistered(name string) *Logger {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for {
+		if l, ok := registry[name]; ok {
+			return l
+		}
+		if name == "" {
+			return rootLogger()
+		}
+		name = parentName(name)
+	}
+}
+
+// parentName returns the name of the parent of the registered logger with the given name.
+func parentName(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[:i]
+	}
+	return ""
+}
+
+var (
+	root     *Logger
+	rootOnce sync.Once
+)
+
+// rootLogger returns the root logger, creating it with the Default settings if it doesn't exist.
+func rootLogger() *Logger {
+	rootOnce.Do(func() {
+		defaultsMu.RLock()
+		defer defaultsMu.RUnlock()
+
+		root = &Logger{}
+		root.SetHandler(defaultHandler())
+		root.SetOutput(DefaultWriter)
+		root.SetLogLevel(DefaultLogLevel)
+		root.SetDateFormat(DefaultDateFormat)
+	})
+	return root
+}
+
+// defaultHandler returns the handler of the root logger: DefaultDispatcher if it
+// was assigned a dispatcher of its own, or DefaultHandler otherwise.
+// The caller must hold defaultsMu.
+func defaultHandler() Handler {
+	if DefaultDispatcher != nil && reflect.ValueOf(DefaultDispatcher).Pointer() != reflect.ValueOf(DefaultLogDispatcher).Pointer() {
+		return DefaultDispatcher
+	}
+	return DefaultHandler
+}
+
+// Named returns a child logger whose name is this logger name followed by a dot and the given name.
+//
+// If this logger is registered, the child is the registered logger returned by Get
+// with the full name. Otherwise, the child inherits the settings of this logger and
+// keeps its fields, like the loggers created by With.
+func (l *Logger) Named(name string) *Logger {
+	if l.name != "" {
+		name = l.name + "." + name
+	}
+	if l.isRegistered() {
+		return Get(name)
+	}
+
+	child := l.With()
+	child.name = name
+	return child
+}
+
+// isRegistered reports whether the logger is the registered logger with its name.
+func (l *Logger) isRegistered() bool {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	return registry[l.name] == l
+}
@@ -0,0 +1,120 @@
+package rotate
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// backupTimeFormat is the format of the rotation time in the names of the rotated files.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// compressSuffix is appended to the names of the compressed rotated files.
+const compressSuffix = ".gz"
+
+// backup is a rotated file.
+type backup struct {
+	path       string
+	time       time.Time
+	compressed bool
+}
+
+// backupName returns the name of the file rotated at the given time, with the
+// time inserted between the name and the extension of the path.
+func backupName(path string, t time.Time) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// listBackups returns the rotated files of the path, from the newest to the oldest.
+// The rotation times in their names are read in the given location.
+func listBackups(path string, loc *time.Location) ([]backup, error) {
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(path)
+	prefix := strings.TrimSuffix(filepath.Base(path), ext) + "-"
+
+	var backups []backup
+	for _, entry := range entries {
+		name, compressed := strings.CutSuffix(entry.Name(), compressSuffix)
+		stamp, ok := strings.CutPrefix(name, prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		if stamp, ok = strings.CutSuffix(stamp, ext); !ok {
+			continue
+		}
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, loc)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(filepath.Dir(path), entry.Name()), time: t, compressed: compressed})
+	}
+
+	slices.SortFunc(backups, func(a, b backup) int {
+		return b.time.Compare(a.time)
+	})
+	return backups, nil
+}
+
+// cleanupBackups removes the rotated files of the path beyond MaxBackups or
+// older than MaxAge, and compresses the others if Compress is set.
+//
+// The cleanup is best effort: files that cannot be removed or compressed are
+// tried again on the next cleanup.
+func cleanupBackups(path string, opts Options) {
+	now := opts.Now()
+	backups, err := listBackups(path, now.Location())
+	if err != nil {
+		return
+	}
+
+	cutoff := now.Add(-opts.MaxAge)
+	for i, b := range backups {
+		if opts.MaxBackups > 0 && i >= opts.MaxBackups || opts.MaxAge > 0 && b.time.Before(cutoff) {
+			os.Remove(b.path)
+		} else if opts.Compress && !b.compressed {
+			compressFile(b.path, opts.FileMode)
+		}
+	}
+}
+
+// compressFile replaces the file by its gzip compressed version.
+func compressFile(path string, mode os.FileMode) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+compressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(dst.Name())
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
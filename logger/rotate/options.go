@@ -0,0 +1,65 @@
+package rotate
+
+import (
+	"os"
+	"time"
+)
+
+// Interval defines how often a FileWriter rotates its file.
+type Interval uint8
+
+const (
+	// IntervalNone disables the rotation by time.
+	IntervalNone Interval = iota
+	// IntervalHourly rotates the file at the start of every hour.
+	IntervalHourly
+	// IntervalDaily rotates the file at midnight.
+	IntervalDaily
+)
+
+// Options configures a FileWriter.
+type Options struct {
+	// MaxSize is the size in bytes above which the file is rotated.
+	// Defaults to 0, which disables the rotation by size.
+	MaxSize int64
+	// Interval is how often the file is rotated.
+	// Defaults to IntervalNone.
+	Interval Interval
+	// MaxBackups is the number of rotated files to keep.
+	// Defaults to 0, which keeps every rotated file not removed by MaxAge.
+	MaxBackups int
+	// MaxAge is how long rotated files are kept, such as 7 * 24 * time.Hour.
+	// Defaults to 0, which keeps every rotated file not removed by MaxBackups.
+	MaxAge time.Duration
+	// Compress enables the gzip compression of the rotated files.
+	Compress bool
+	// FileMode is the permission of the created files.
+	// Defaults to 0644.
+	FileMode os.FileMode
+	// Now returns the current time, used for the rotation by time and the names
+	// of the rotated files. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// withDefaults returns the options with the defaults applied.
+func (o Options) withDefaults() Options {
+	if o.FileMode == 0 {
+		o.FileMode = 0644
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// periodStart returns the start of the rotation period that contains t, or
+// the zero time if the file is not rotated by time.
+func (i Interval) periodStart(t time.Time) time.Time {
+	switch i {
+	case IntervalHourly:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case IntervalDaily:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
@@ -0,0 +1,24 @@
+package rotate
+
+// Reopen closes the file and opens its path again, creating it if needed.
+//
+// Use it after the file was renamed or removed by an external tool such as
+// logrotate. Reopen waits for the write in progress, and the writes made
+// afterwards go to the new file, including the writes still queued by an
+// async.AsyncWriter in front of the writer. Flush the AsyncWriter before Reopen
+// to write them to the renamed file instead.
+//
+// If the path cannot be opened, the next writes try again and return the error.
+func (w *FileWriter) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	if w.file != nil {
+		w.file.Close()
+		w.file = nil
+	}
+	return w.open()
+}
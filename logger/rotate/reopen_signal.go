@@ -0,0 +1,60 @@
+//go:build unix || windows
+
+package rotate
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/ecromaneli-golang/console/logger/async"
+)
+
+// ReopenOnSignal calls Reopen whenever the process receives one of the signals,
+// or SIGHUP if none is given, until the returned function is called or the
+// writer is closed. It installs nothing and returns a no-op function if the
+// writer is already closed.
+//
+// The AsyncWriters are flushed with async.FlushAll before reopening, so the
+// messages logged before the signal are written to the renamed file.
+func (w *FileWriter) ReopenOnSignal(sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{syscall.SIGHUP}
+	}
+
+	// Close runs the stop functions with mu held, so none is added after it
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return func() {}
+	}
+
+	received := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(received, sigs...)
+
+	go func() {
+		for {
+			select {
+			case <-received:
+				async.FlushAll()
+				w.Reopen()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+
+	w.stops = append(w.stops, stop)
+	return stop
+}
@@ -0,0 +1,219 @@
+package rotate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Write after the FileWriter has been closed.
+var ErrClosed = errors.New("rotate: write to closed writer")
+
+// FileWriter is an io.Writer that appends to a file and rotates it by size and by time.
+//
+// Rotating renames the file with the time of the rotation, as "app-2006-01-02T15-04-05.000.log"
+// for "app.log", and creates a new file. The rotated files are then compressed and
+// removed in the background, according to the options.
+//
+// Without rotation options, it is a plain file writer that can be reopened when
+// the file is rotated by an external tool, with Reopen or ReopenOnSignal.
+//
+// It is safe for concurrent use. The rotation happens within Write, so no data is
+// lost or written out of order when writes are queued by an async.AsyncWriter.
+type FileWriter struct {
+	path    string
+	opts    Options       // Settings with the defaults applied
+	mu      sync.Mutex    // Guards the fields below
+	file    *os.File      // The open file, or nil if it must be opened
+	size    int64         // Size of the open file
+	period  time.Time     // Start of the rotation period of the open file
+	closed  bool          // Set by Close
+	stops   []func()      // Stop the signal handlers installed by ReopenOnSignal
+	cleanup chan struct{} // Signals the background goroutine that backups must be cleaned up
+	done    chan struct{} // Closed when the background goroutine exits
+}
+
+// NewFileWriter creates a FileWriter that appends to the file at the path,
+// creating it and its directory if needed.
+func NewFileWriter(path string, opts Options) (*FileWriter, error) {
+	w := &FileWriter{
+		path:    path,
+		opts:    opts.withDefaults(),
+		cleanup: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	go w.run()
+	w.signalCleanup()
+	return w, nil
+}
+
+// Write appends p to the file, rotating it first if needed.
+func (w *FileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	}
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Rotate rotates the file, even if it is not due.
+func (w *FileWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	if w.file == nil {
+		if err := w.open(); err != nil {
+			return err
+		}
+	}
+	return w.rotate()
+}
+
+// Close closes the file and waits for the rotated files to be compressed and removed.
+//
+// Writes after Close return ErrClosed, and the signal handlers installed by
+// ReopenOnSignal are removed.
+func (w *FileWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.cleanup)
+	for _, stop := range w.stops {
+		stop()
+	}
+
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+
+	<-w.done
+	return err
+}
+
+// Path returns the path of the file.
+func (w *FileWriter) Path() string {
+	return w.path
+}
+
+// shouldRotate reports whether the file must be rotated before writing n bytes.
+//
+// An empty file is not rotated: it starts the new period instead of being rotated
+// by time, and receives the data of a write larger than MaxSize.
+func (w *FileWriter) shouldRotate(n int) bool {
+	if w.opts.MaxSize > 0 && w.size > 0 && w.size+int64(n) > w.opts.MaxSize {
+		return true
+	}
+	if w.opts.Interval == IntervalNone {
+		return false
+	}
+
+	period := w.opts.Interval.periodStart(w.opts.Now())
+	if w.size == 0 {
+		w.period = period
+	}
+	return !period.Equal(w.period)
+}
+
+// open opens the file for appending, creating it and its directory if needed.
+// The rotation period of an existing file starts at its last modification.
+func (w *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(w.path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, w.opts.FileMode)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.period = w.opts.Interval.periodStart(w.opts.Now())
+	if w.size > 0 {
+		w.period = w.opts.Interval.periodStart(info.ModTime().In(w.period.Location()))
+	}
+	return nil
+}
+
+// rotate renames the open file to a backup name and opens a new file.
+func (w *FileWriter) rotate() error {
+	// Rotations within the same millisecond get the next free name
+	t := w.opts.Now()
+	for {
+		_, err := os.Lstat(backupName(w.path, t))
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		t = t.Add(time.Millisecond)
+	}
+
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	w.file = nil
+
+	if err := os.Rename(w.path, backupName(w.path, t)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := w.open(); err != nil {
+		return err
+	}
+
+	w.signalCleanup()
+	return nil
+}
+
+// signalCleanup signals the background goroutine that the backups must be cleaned up.
+func (w *FileWriter) signalCleanup() {
+	select {
+	case w.cleanup <- struct{}{}:
+	default:
+	}
+}
+
+// run cleans up the backups when signalled, until the writer is closed.
+func (w *FileWriter) run() {
+	defer close(w.done)
+
+	for range w.cleanup {
+		cleanupBackups(w.path, w.opts)
+	}
+}
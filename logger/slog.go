@@ -0,0 +1,185 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+)
+
+const (
+	// SlogLevelTrace is the slog level that LevelTrace maps to.
+	SlogLevelTrace = slog.Level(-8)
+	// SlogLevelFatal is the slog level that LevelFatal maps to.
+	SlogLevelFatal = slog.Level(12)
+	// SlogLevelOff is the slog level that LevelOff maps to. No record reaches it.
+	SlogLevelOff = slog.Level(math.MaxInt)
+	// SlogLevelAll is the slog level that LevelAll maps to. Every record reaches it.
+	SlogLevelAll = slog.Level(math.MinInt)
+)
+
+// ToSlogLevel converts a Level to the equivalent slog.Level.
+//
+// The named levels map to slog.LevelDebug, slog.LevelInfo, slog.LevelWarn and
+// slog.LevelError, and to SlogLevelTrace, SlogLevelFatal, SlogLevelOff and
+// SlogLevelAll. Every 5 steps of Level are 4 steps of slog.Level, so the levels
+// in between are scaled and rounded toward LevelInfo.
+func ToSlogLevel(l Level) slog.Level {
+	switch l {
+	case LevelOff:
+		return SlogLevelOff
+	case LevelAll:
+		return SlogLevelAll
+	}
+	return slog.Level((int(LevelInfo) - int(l)) * 4 / 5)
+}
+
+// FromSlogLevel converts a slog.Level to the equivalent Level.
+//
+// It is the inverse of ToSlogLevel, so the named levels round-trip exactly, as do
+// the slog levels that are multiples of 4. The result is clamped between the most
+// severe level above LevelOff and the most verbose level below LevelAll.
+func FromSlogLevel(s slog.Level) Level {
+	switch s {
+	case SlogLevelOff:
+		return LevelOff
+	case SlogLevelAll:
+		return LevelAll
+	}
+
+	// Levels far outside the range of Level are clamped first, so scaling cannot overflow
+	s = min(max(s, -1024), 1024)
+	l := int64(LevelInfo) - int64(s)*5/4
+	return Level(min(max(l, int64(LevelOff)+1), int64(LevelAll)-1))
+}
+
+// SlogHandler is a slog.Handler backed by a Logger.
+//
+// Records are enabled according to the logger level and written with its name,
+// output, date format and handler. Attributes become fields, and the keys of
+// grouped attributes are prefixed with the group names joined by dots.
+type SlogHandler struct {
+	logger *Logger
+	fields []Field
+	prefix string
+}
+
+// NewSlogHandler creates a slog.Handler that logs through the given logger.
+//
+// Use slog.New(logger.NewSlogHandler(l)) to obtain a *slog.Logger.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{logger: l}
+}
+
+// Enabled reports whether the logger is enabled for the level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.IsEnabled(FromSlogLevel(level))
+}
+
+// Handle converts the slog record to a Record and passes it to the logger handler.
+func (h *SlogHandler) Handle(_ context.Context, sr slog.Record) error {
+	fields := make([]Field, 0, len(h.logger.fields)+len(h.fields)+sr.NumAttrs())
+	fields = append(append(fields, h.logger.fields...), h.fields...)
+	sr.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, h.prefix, a)
+		return true
+	})
+
+	r := &Record{
+		Time:   sr.Time,
+		Level:  FromSlogLevel(sr.Level),
+		Args:   []any{sr.Message},
+		Fields: fields,
+	}
+	if h.logger.ReportCaller() {
+		r.PC = sr.PC
+	}
+
+	h.logger.handle(r)
+	return nil
+}
+
+// WithAttrs returns a handler that adds the attributes to every record.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	child := *h
+	child.fields = make([]Field, len(h.fields), len(h.fields)+len(attrs))
+	copy(child.fields, h.fields)
+	for _, a := range attrs {
+		child.fields = appendAttr(child.fields, h.prefix, a)
+	}
+	return &child
+}
+
+// WithGroup returns a handler that prefixes the keys of the following attributes with the group name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	child := *h
+	child.prefix = h.prefix + name + "."
+	return &child
+}
+
+// appendAttr appends the resolved attribute as fields, flattening groups into dotted keys.
+func appendAttr(fields []Field, prefix string, a slog.Attr) []Field {
+	a.Value = a.Value.Resolve()
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			fields = appendAttr(fields, prefix, ga)
+		}
+		return fields
+	}
+
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	return append(fields, Field{Key: prefix + a.Key, Value: a.Value.Any()})
+}
+
+// SlogForwarder is a Handler that forwards records to a slog.Handler.
+//
+// The output of the logger is not used. The logger name is added as an
+// attribute under NameKey, unless the name or NameKey is empty.
+type SlogForwarder struct {
+	// Handler is the slog.Handler that receives the records.
+	Handler slog.Handler
+	// NameKey is the attribute key for the logger name.
+	NameKey string
+}
+
+// NewSlogForwarder creates a Handler that forwards records to the slog.Handler.
+//
+// The logger name is added under the "logger" key.
+func NewSlogForwarder(h slog.Handler) *SlogForwarder {
+	return &SlogForwarder{Handler: h, NameKey: "logger"}
+}
+
+// Handle converts the record to a slog.Record and passes it to the slog.Handler.
+func (f *SlogForwarder) Handle(_ io.Writer, r *Record) {
+	ctx := context.Background()
+	level := ToSlogLevel(r.Level)
+
+	if !f.Handler.Enabled(ctx, level) {
+		return
+	}
+
+	sr := slog.NewRecord(r.Time, level, r.Message(), r.PC)
+	if f.NameKey != "" && r.Name != "" {
+		sr.AddAttrs(slog.String(f.NameKey, r.Name))
+	}
+	for _, field := range r.Fields {
+		sr.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+
+	f.Handler.Handle(ctx, sr)
+}
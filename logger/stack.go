@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+)
+
+// maxStackDepth is the maximum number of frames captured in a stack trace.
+const maxStackDepth = 64
+
+// captureStack returns the stack trace of the current goroutine, skipping the
+// given number of frames, as "function\n\tfile:line\n" for each frame.
+//
+// It is preceded by the "%+v" representation of every error in args or in the
+// field values whose chain carries more details than its message, such as the
+// stack traces recorded by some error packages.
+func captureStack(skip int, args []any, fields []Field) string {
+	var buf []byte
+
+	for _, arg := range args {
+		buf = appendErrorDetails(buf, arg)
+	}
+	for _, field := range fields {
+		buf = appendErrorDetails(buf, field.Value)
+	}
+
+	var pcs [maxStackDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(skip+1, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		buf = append(buf, frame.Function...)
+		buf = append(buf, "\n\t"...)
+		buf = append(buf, frame.File...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(frame.Line), 10)
+		buf = append(buf, '\n')
+		if !more {
+			break
+		}
+	}
+
+	return string(buf)
+}
+
+// appendErrorDetails appends the "%+v" representation of the first error in the
+// chain of v that implements fmt.Formatter and adds details to its message.
+func appendErrorDetails(buf []byte, v any) []byte {
+	err, ok := v.(error)
+	if !ok {
+		return buf
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(fmt.Formatter); !ok {
+			continue
+		}
+
+		if details := fmt.Sprintf("%+v", e); details != e.Error() {
+			buf = append(buf, details...)
+			if buf[len(buf)-1] != '\n' {
+				buf = append(buf, '\n')
+			}
+			return buf
+		}
+	}
+
+	return buf
+}
+
+// appendIndented appends every line of text prefixed by the indent.
+func appendIndented(buf []byte, text string, indent string) []byte {
+	start := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\n' {
+			buf = append(buf, indent...)
+			buf = append(buf, text[start:i+1]...)
+			start = i + 1
+		}
+	}
+
+	if start < len(text) {
+		buf = append(buf, indent...)
+		buf = append(buf, text[start:]...)
+		buf = append(buf, '\n')
+	}
+
+	return buf
+}
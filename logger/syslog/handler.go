@@ -0,0 +1,217 @@
+package syslog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// Handler is a logger.Handler that formats records as syslog messages.
+//
+// Each record is written to the logger output with a single Write call, without
+// a trailing newline, so the output is expected to be a Writer, which frames the
+// messages for the transport. An async.AsyncWriter in front of the Writer must not
+// coalesce the writes, so its MaxBatchBytes option must be left at zero.
+type Handler struct {
+	opts    Options // Settings with the defaults applied
+	procID  string
+	bufPool sync.Pool
+}
+
+// NewHandler creates a Handler with the given options.
+func NewHandler(opts Options) *Handler {
+	if opts.Facility == Kern {
+		opts.Facility = User
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	if opts.StructuredDataID == "" {
+		opts.StructuredDataID = "fields@32473"
+	}
+
+	return &Handler{
+		opts:   opts,
+		procID: strconv.Itoa(os.Getpid()),
+		bufPool: sync.Pool{New: func() any {
+			buf := make([]byte, 0, 1024)
+			return &buf
+		}},
+	}
+}
+
+// Handle formats the record as a syslog message and writes it to w.
+func (h *Handler) Handle(w io.Writer, r *logger.Record) {
+	buf := h.bufPool.Get().(*[]byte)
+	defer func() {
+		*buf = (*buf)[:0]
+		h.bufPool.Put(buf)
+	}()
+
+	if h.opts.Format == RFC3164 {
+		*buf = h.appendRFC3164(*buf, r)
+	} else {
+		*buf = h.appendRFC5424(*buf, r)
+	}
+	w.Write(*buf)
+}
+
+// appendRFC5424 appends the record as "<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG".
+func (h *Handler) appendRFC5424(buf []byte, r *logger.Record) []byte {
+	appName, msgID := h.appName(r.Name), r.Name
+	if !h.opts.NameAsMsgID {
+		msgID = ""
+	}
+
+	buf = h.appendPriority(buf, r.Level)
+	buf = append(buf, '1', ' ')
+	buf = r.Time.AppendFormat(buf, "2006-01-02T15:04:05.000000Z07:00")
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, h.opts.Hostname, 255)
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, appName, 48)
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, h.procID, 128)
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, msgID, 32)
+	buf = append(buf, ' ')
+
+	// Add the fields and the call site as structured data
+	if len(r.Fields) == 0 && r.PC == 0 {
+		buf = append(buf, '-')
+	} else {
+		buf = append(buf, '[')
+		buf = appendHeaderField(buf, h.opts.StructuredDataID, 32)
+		if r.PC != 0 {
+			buf = append(buf, ` caller="`...)
+			buf = appendParamValue(buf, string(r.AppendCaller(nil)))
+			buf = append(buf, '"')
+		}
+		for _, field := range r.Fields {
+			buf = append(buf, ' ')
+			buf = appendParamName(buf, field.Key)
+			buf = append(buf, `="`...)
+			buf = appendParamValue(buf, valueString(field.Value))
+			buf = append(buf, '"')
+		}
+		buf = append(buf, ']')
+	}
+
+	buf = append(buf, ' ')
+	return h.appendMessage(buf, r)
+}
+
+// appendRFC3164 appends the record as "<PRI>TIMESTAMP HOSTNAME TAG[PID]: MSG".
+func (h *Handler) appendRFC3164(buf []byte, r *logger.Record) []byte {
+	buf = h.appendPriority(buf, r.Level)
+	buf = r.Time.AppendFormat(buf, "Jan _2 15:04:05")
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, h.opts.Hostname, 255)
+	buf = append(buf, ' ')
+	buf = appendHeaderField(buf, h.appName(r.Name), 32)
+	buf = append(buf, '[')
+	buf = append(buf, h.procID...)
+	buf = append(buf, "]: "...)
+
+	if r.PC != 0 {
+		buf = r.AppendCaller(buf)
+		buf = append(buf, ": "...)
+	}
+	buf = r.AppendMessage(buf)
+	for _, field := range r.Fields {
+		buf = append(buf, ' ')
+		buf = append(buf, field.String()...)
+	}
+	if r.Stack != "" {
+		buf = append(buf, '\n')
+		buf = append(buf, r.Stack...)
+	}
+	return buf
+}
+
+// appendPriority appends the PRI part, the facility and the severity of the level.
+func (h *Handler) appendPriority(buf []byte, lv logger.Level) []byte {
+	buf = append(buf, '<')
+	buf = strconv.AppendUint(buf, uint64(h.opts.Facility)*8+uint64(SeverityOf(lv)), 10)
+	return append(buf, '>')
+}
+
+// appendMessage appends the message followed by the stack trace, if any.
+func (h *Handler) appendMessage(buf []byte, r *logger.Record) []byte {
+	buf = r.AppendMessage(buf)
+	if r.Stack != "" {
+		buf = append(buf, '\n')
+		buf = append(buf, r.Stack...)
+	}
+	return buf
+}
+
+// appName returns the APP-NAME of the messages of the logger.
+func (h *Handler) appName(name string) string {
+	switch {
+	case h.opts.AppName != "":
+		return h.opts.AppName
+	case name != "" && !h.opts.NameAsMsgID:
+		return name
+	}
+	return filepath.Base(os.Args[0])
+}
+
+// appendHeaderField appends a header field, replacing the characters that are
+// not printable ASCII by underscores and truncating it to the maximum length.
+// An empty field is appended as the nil value "-".
+func appendHeaderField(buf []byte, field string, maxLen int) []byte {
+	if field == "" {
+		return append(buf, '-')
+	}
+
+	for i := 0; i < len(field) && i < maxLen; i++ {
+		if c := field[i]; c > ' ' && c <= '~' {
+			buf = append(buf, c)
+		} else {
+			buf = append(buf, '_')
+		}
+	}
+	return buf
+}
+
+// appendParamName appends a structured data parameter name, replacing the
+// characters that are not allowed by underscores.
+func appendParamName(buf []byte, name string) []byte {
+	if name == "" {
+		return append(buf, '_')
+	}
+
+	for i := 0; i < len(name) && i < 32; i++ {
+		if c := name[i]; c > ' ' && c <= '~' && c != '=' && c != ']' && c != '"' {
+			buf = append(buf, c)
+		} else {
+			buf = append(buf, '_')
+		}
+	}
+	return buf
+}
+
+// appendParamValue appends a structured data parameter value, escaping '"', '\' and ']'.
+func appendParamValue(buf []byte, value string) []byte {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '"' || c == '\\' || c == ']' {
+			buf = append(buf, '\\')
+		}
+		buf = append(buf, value[i])
+	}
+	return buf
+}
+
+// valueString returns the text of a field value.
+func valueString(value any) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprint(value)
+}
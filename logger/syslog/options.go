@@ -0,0 +1,105 @@
+package syslog
+
+import "github.com/ecromaneli-golang/console/logger"
+
+// Format is the syslog message format written by a Handler.
+type Format uint8
+
+const (
+	// RFC5424 is the format of RFC 5424, with the fields as structured data.
+	RFC5424 Format = iota
+	// RFC3164 is the BSD format of RFC 3164, with the fields appended to the message.
+	RFC3164
+)
+
+// Facility is the syslog facility of the messages.
+type Facility uint8
+
+// The facilities defined by RFC 5424 for the programs.
+const (
+	Kern Facility = iota
+	User
+	Mail
+	Daemon
+	Auth
+	Syslog
+	LPR
+	News
+	UUCP
+	Cron
+	AuthPriv
+	FTP
+	_ // NTP
+	_ // Log audit
+	_ // Log alert
+	_ // Clock daemon
+	Local0
+	Local1
+	Local2
+	Local3
+	Local4
+	Local5
+	Local6
+	Local7
+)
+
+// Severity is the syslog severity of a message.
+type Severity uint8
+
+// The severities defined by RFC 5424, from the most severe.
+const (
+	Emergency Severity = iota
+	Alert
+	Critical
+	Error
+	Warning
+	Notice
+	Informational
+	Debug
+)
+
+// SeverityOf returns the syslog severity of a level.
+//
+// LevelFatal is Critical, LevelError is Error, LevelWarn is Warning, LevelInfo is
+// Informational and the levels above are Debug. The custom levels between
+// LevelWarn and LevelInfo, such as a NOTICE level, are Notice, and the others
+// have the severity of the next predefined level.
+func SeverityOf(lv logger.Level) Severity {
+	switch {
+	case lv <= logger.LevelFatal:
+		return Critical
+	case lv <= logger.LevelError:
+		return Error
+	case lv <= logger.LevelWarn:
+		return Warning
+	case lv < logger.LevelInfo:
+		return Notice
+	case lv == logger.LevelInfo:
+		return Informational
+	}
+	return Debug
+}
+
+// Options configures a Handler.
+type Options struct {
+	// Format is the message format.
+	// Defaults to RFC5424.
+	Format Format
+	// Facility is the facility of the messages.
+	// Defaults to User, as Kern is reserved for the kernel.
+	Facility Facility
+	// Hostname is the HOSTNAME of the messages.
+	// Defaults to the host name reported by the kernel.
+	Hostname string
+	// AppName is the APP-NAME of the messages, or the TAG in the RFC3164 format.
+	// Defaults to the logger name, or to the program name if the logger name is
+	// empty or is the MSGID.
+	AppName string
+	// NameAsMsgID sets the logger name as the MSGID of the messages instead of the
+	// APP-NAME. It is ignored in the RFC3164 format.
+	NameAsMsgID bool
+	// StructuredDataID is the SD-ID of the structured data element holding the
+	// fields in the RFC5424 format, such as "fields@32473" with your private
+	// enterprise number. Defaults to "fields@32473".
+	StructuredDataID string
+}
@@ -0,0 +1,133 @@
+package syslog
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Write after the Writer has been closed.
+var ErrClosed = errors.New("syslog: write to closed writer")
+
+// dialTimeout is the maximum time to connect to the syslog server.
+const dialTimeout = 10 * time.Second
+
+// localSockets are the paths of the local syslog socket on the common systems.
+var localSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// Writer is an io.Writer that sends each write as a syslog message.
+//
+// Over datagram transports, such as "udp" and "unixgram", every write is a
+// datagram. Over "tcp", writes are framed by octet counting, as in RFC 6587, and
+// over "unix" stream sockets they are terminated by a newline.
+//
+// When a write fails, the Writer reconnects and sends the message again once.
+// If it still fails, the error is returned and the next write reconnects.
+//
+// It is safe for concurrent use.
+type Writer struct {
+	network string
+	address string
+	mu      sync.Mutex // Guards the fields below
+	conn    net.Conn   // The connection, or nil if it must be established
+	closed  bool       // Set by Close
+}
+
+// Dial connects to the syslog server at the address on the network, such as
+// "udp" and "localhost:514", "tcp" and "logs.example.com:6514", or "unixgram"
+// and "/dev/log".
+//
+// If the network and the address are empty, it connects to the local syslog
+// socket, trying "unixgram" and then "unix" on the usual paths.
+func Dial(network, address string) (*Writer, error) {
+	w := &Writer{network: network, address: address}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write sends p as a single syslog message.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return 0, ErrClosed
+	}
+
+	if w.conn != nil {
+		if err := w.send(p); err == nil {
+			return len(p), nil
+		}
+		w.conn.Close()
+		w.conn = nil
+	}
+
+	if err := w.connect(); err != nil {
+		return 0, err
+	}
+	if err := w.send(p); err != nil {
+		w.conn.Close()
+		w.conn = nil
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection. Writes after Close return ErrClosed.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.conn != nil {
+		return w.conn.Close()
+	}
+	return nil
+}
+
+// connect establishes the connection.
+func (w *Writer) connect() error {
+	if w.network != "" || w.address != "" {
+		conn, err := net.DialTimeout(w.network, w.address, dialTimeout)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+		return nil
+	}
+
+	var err error
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range localSockets {
+			var conn net.Conn
+			if conn, err = net.DialTimeout(network, path, dialTimeout); err == nil {
+				w.conn = conn
+				return nil
+			}
+		}
+	}
+	return err
+}
+
+// send writes the message to the connection with the framing of its transport.
+func (w *Writer) send(p []byte) error {
+	var err error
+	switch w.conn.LocalAddr().Network() {
+	case "tcp", "tcp4", "tcp6":
+		frame := strconv.AppendInt(make([]byte, 0, len(p)+8), int64(len(p)), 10)
+		frame = append(frame, ' ')
+		_, err = w.conn.Write(append(frame, p...))
+	case "unix":
+		_, err = w.conn.Write(append(p[:len(p):len(p)], '\n'))
+	default:
+		_, err = w.conn.Write(p)
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ecromaneli-golang/console/logger/async"
+)
+
+// EnabledHandler is a Handler that only handles the records of some levels.
+//
+// Loggers skip the messages of the levels that their handler does not enable,
+// in addition to the levels below the logger level.
+type EnabledHandler interface {
+	Handler
+	// Enabled reports whether the handler handles the records of the level.
+	Enabled(lv Level) bool
+}
+
+// Branch is an output of a TeeHandler.
+type Branch struct {
+	// Level is the minimum level of the records written to the branch.
+	Level Level
+	// Handler formats the records of the branch.
+	Handler Handler
+	// Writer is where the branch writes. Defaults to the logger output.
+	Writer io.Writer
+	// Async writes to the Writer through an async.AsyncWriter configured by AsyncOptions.
+	// It is ignored for the branches without a writer.
+	Async bool
+	// AsyncOptions configures the async.AsyncWriter of an asynchronous branch.
+	// The zero value writes every message separately, as needed by syslog and journald writers.
+	AsyncOptions async.Options
+}
+
+// TeeHandler passes each record to the branches whose level enables it, so one
+// logger can write to several outputs, each with its own level and format.
+//
+// Set the level of the logger to LevelAll to let the branches select the records:
+// the logger skips the messages that no branch enables.
+type TeeHandler struct {
+	branches []Branch
+	level    Level // The lowest level of the branches
+}
+
+// NewTeeHandler creates a TeeHandler with the given branches.
+//
+// The writers of the asynchronous branches are wrapped in an async.AsyncWriter,
+// which is flushed by Flush and closed by Close.
+func NewTeeHandler(branches ...Branch) *TeeHandler {
+	t := &TeeHandler{branches: make([]Branch, len(branches))}
+	for i, branch := range branches {
+		if branch.Async && branch.Writer != nil {
+			branch.Writer = async.NewAsyncWriterWithOptions(branch.Writer, branch.AsyncOptions)
+		}
+		t.branches[i] = branch
+		t.level = max(t.level, branch.Level)
+	}
+	return t
+}
+
+// Handle passes the record to the branches whose level enables it.
+// The branches without a writer write to w.
+func (t *TeeHandler) Handle(w io.Writer, r *Record) {
+	for i := range t.branches {
+		branch := &t.branches[i]
+		if branch.Level < r.Level {
+			continue
+		}
+		if branch.Writer != nil {
+			branch.Handler.Handle(branch.Writer, r)
+		} else {
+			branch.Handler.Handle(w, r)
+		}
+	}
+}
+
+// Enabled reports whether a branch handles the records of the level.
+func (t *TeeHandler) Enabled(lv Level) bool {
+	return t.level >= lv
+}
+
+// Flush waits for the pending writes of the branches writing to an async.AsyncWriter to complete.
+func (t *TeeHandler) Flush() {
+	for _, branch := range t.branches {
+		if asyncWriter, ok := branch.Writer.(*async.AsyncWriter); ok {
+			asyncWriter.Flush()
+		}
+	}
+}
+
+// Close waits for the pending writes of the asynchronous branches to complete
+// and closes the async.AsyncWriter created for them. The writers they wrap are not closed.
+func (t *TeeHandler) Close() error {
+	var errs []error
+	for _, branch := range t.branches {
+		if asyncWriter, ok := branch.Writer.(*async.AsyncWriter); ok && branch.Async {
+			errs = append(errs, asyncWriter.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
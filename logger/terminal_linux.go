@@ -0,0 +1,5 @@
+package logger
+
+import "syscall"
+
+const ioctlReadTermios = syscall.TCGETS
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package logger
+
+// isTerminal reports whether the file descriptor is a terminal.
+// Terminals are not detected on this platform.
+func isTerminal(fd uintptr) bool {
+	return false
+}
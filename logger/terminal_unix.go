@@ -0,0 +1,15 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package logger
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal reports whether the file descriptor is a terminal.
+func isTerminal(fd uintptr) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlReadTermios, uintptr(unsafe.Pointer(&termios)))
+	return errno == 0
+}
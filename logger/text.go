@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"io"
+	"sync"
+)
+
+// TextHandler formats records in the human readable "DATE - LEVEL name: message" layout.
+//
+// Fields are appended to the message as key=value pairs. The call site, if
+// captured, follows the name as "dir/file.go:line", and the stack trace, if
+// captured, follows the message with every line indented by a tab.
+type TextHandler struct{}
+
+// Handle formats the record and writes it to w with a single Write call.
+func (TextHandler) Handle(w io.Writer, r *Record) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	*buf = appendText(*buf, r, textColors{})
+
+	// Write the final message to the writer
+	w.Write(*buf)
+}
+
+// textColors are the ANSI SGR parameters of the parts of the text layout.
+// An empty color leaves the part uncolored.
+type textColors struct {
+	time  string
+	level string
+	name  string
+}
+
+// appendText appends the record in the text layout, with the given colors.
+func appendText(buf []byte, r *Record, colors textColors) []byte {
+	// Add the timestamp if a date format is provided
+	if r.DateFormat != "" {
+		buf = appendColorStart(buf, colors.time)
+		buf = r.Time.AppendFormat(buf, r.DateFormat)
+		buf = appendColorEnd(buf, colors.time)
+		buf = append(buf, " - "...)
+	}
+
+	// Add the log level
+	buf = appendColorStart(buf, colors.level)
+	buf = append(buf, r.Level.String()...)
+	buf = appendColorEnd(buf, colors.level)
+	buf = appendLevelPadding(buf, r.Level)
+
+	// Add the logger name and the call site if provided
+	if r.Name != "" || r.PC != 0 {
+		if r.Name != "" {
+			buf = append(buf, ' ')
+			buf = appendColorStart(buf, colors.name)
+			buf = append(buf, r.Name...)
+			buf = appendColorEnd(buf, colors.name)
+		}
+		if r.PC != 0 {
+			buf = append(buf, ' ')
+			buf = r.AppendCaller(buf)
+		}
+		buf = append(buf, ':')
+	}
+
+	// Add a space before the message
+	buf = append(buf, ' ')
+
+	// Add the log message followed by the fields
+	start := len(buf)
+	buf = r.AppendMessage(buf)
+	for i, field := range r.Fields {
+		if i > 0 || len(buf) > start {
+			buf = append(buf, ' ')
+		}
+		buf = appendField(buf, field)
+	}
+	buf = append(buf, '\n')
+
+	// Add the stack trace under the message
+	if r.Stack != "" {
+		buf = appendIndented(buf, r.Stack, "\t")
+	}
+	return buf
+}
+
+// DefaultLogDispatcher is the default function for formatting and writing log messages.
+//
+// It formats the message with a timestamp, log level, name, and the message content.
+// It is the LogDispatcher form of TextHandler.
+func DefaultLogDispatcher(w io.Writer, dateFormat string, name string, l Level, a ...any) {
+	handleDispatch(TextHandler{}, w, dateFormat, name, l, a)
+}
+
+// maxPooledBufferSize is the capacity above which buffers are not returned to the pool.
+const maxPooledBufferSize = 64 * 1024
+
+// bufferPool recycles the buffers used to format the records.
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// getBuffer returns an empty buffer from the pool.
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+// putBuffer returns the buffer to the pool, unless it grew too much.
+func putBuffer(buf *[]byte) {
+	if cap(*buf) <= maxPooledBufferSize {
+		*buf = (*buf)[:0]
+		bufferPool.Put(buf)
+	}
+}
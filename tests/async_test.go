@@ -0,0 +1,297 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger/async"
+)
+
+// slowWriter delays every write to keep entries pending in the async writer.
+type slowWriter struct {
+	SafeBuffer
+	delay time.Duration
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return w.SafeBuffer.Write(p)
+}
+
+func TestShouldReturnErrorWhenWritingAfterClose(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	writer := async.NewAsyncWriter(&output, 10)
+
+	// When
+	writer.Write([]byte("1"))
+	closeErr := writer.Close()
+	n, err := writer.Write([]byte("2"))
+
+	// Then
+	AssertEquals(t, nil, closeErr)
+	AssertEquals(t, 0, n)
+	AssertEquals(t, async.ErrClosed, err)
+	AssertEquals(t, true, errors.Is(err, io.ErrClosedPipe))
+	AssertEquals(t, "1", output.String())
+}
+
+func TestShouldNotPanicOnConcurrentWriteAndClose(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	var wg sync.WaitGroup
+	var written sync.Map
+	writer := async.NewAsyncWriter(&output, 4)
+
+	// When
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				if _, err := writer.Write([]byte("x\n")); err == nil {
+					written.Store(g*100+i, true)
+				}
+			}
+		}()
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		writer.Flush()
+	}()
+	go func() {
+		defer wg.Done()
+		writer.Close()
+	}()
+	wg.Wait()
+
+	// Then every accepted write reached the target
+	var accepted int
+	written.Range(func(_, _ any) bool {
+		accepted++
+		return true
+	})
+	AssertEquals(t, accepted, strings.Count(output.String(), "\n"))
+}
+
+func TestShouldDiscardPendingWritesWhenCloseDeadlineExpires(t *testing.T) {
+	// Given
+	output := &slowWriter{delay: 20 * time.Millisecond}
+	writer := async.NewAsyncWriterWithOptions(output, async.Options{BufferSize: 100})
+	for range 50 {
+		writer.Write([]byte("x"))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// When
+	err := writer.CloseContext(ctx)
+
+	// Then
+	AssertEquals(t, true, errors.Is(err, context.DeadlineExceeded))
+	_, err = writer.Write([]byte("x"))
+	AssertEquals(t, async.ErrClosed, err)
+	AssertEquals(t, true, len(output.String()) < 50)
+}
+
+func TestShouldKeepWritingAfterFlush(t *testing.T) {
+	// Given
+	output := &slowWriter{delay: time.Millisecond}
+	writer := async.NewAsyncWriter(output, 100)
+	defer writer.Close()
+
+	// When
+	writer.Write([]byte("1"))
+	writer.Write([]byte("2"))
+	writer.Flush()
+	flushed := output.String()
+	_, err := writer.Write([]byte("3"))
+	writer.Flush()
+
+	// Then
+	AssertEquals(t, "12", flushed)
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "123", output.String())
+}
+
+// gateWriter blocks every write until the gate is opened.
+type gateWriter struct {
+	SafeBuffer
+	entered chan struct{}
+	gate    chan struct{}
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{entered: make(chan struct{}, 1), gate: make(chan struct{})}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	select {
+	case w.entered <- struct{}{}:
+	default:
+	}
+	<-w.gate
+	return w.SafeBuffer.Write(p)
+}
+
+// fillAsyncWriter writes "0" and waits for it to block the target, then fills the buffer of 2 with "1" and "2".
+func fillAsyncWriter(output *gateWriter, policy async.OverflowPolicy) *async.AsyncWriter {
+	writer := async.NewAsyncWriterWithOptions(output, async.Options{BufferSize: 2, Overflow: policy})
+	writer.Write([]byte("0"))
+	<-output.entered
+	writer.Write([]byte("1"))
+	writer.Write([]byte("2"))
+	return writer
+}
+
+func TestShouldDropNewestWriteOnOverflow(t *testing.T) {
+	// Given
+	output := newGateWriter()
+	writer := fillAsyncWriter(output, async.OverflowDropNewest)
+
+	// When
+	n, err := writer.Write([]byte("3"))
+	close(output.gate)
+	writer.Close()
+
+	// Then
+	AssertEquals(t, 1, n)
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "012", output.String())
+	AssertEquals(t, uint64(1), writer.ResetDropped())
+	AssertEquals(t, uint64(0), writer.Dropped())
+}
+
+func TestShouldDropOldestWriteOnOverflow(t *testing.T) {
+	// Given
+	output := newGateWriter()
+	writer := fillAsyncWriter(output, async.OverflowDropOldest)
+
+	// When
+	writer.Write([]byte("3"))
+	writer.Write([]byte("4"))
+	close(output.gate)
+	writer.Flush()
+
+	// Then
+	AssertEquals(t, "034", output.String())
+	AssertEquals(t, uint64(2), writer.Dropped())
+	writer.Close()
+}
+
+func TestShouldBlockOnOverflow(t *testing.T) {
+	// Given
+	output := newGateWriter()
+	writer := fillAsyncWriter(output, async.OverflowBlock)
+	written := make(chan struct{})
+
+	// When
+	go func() {
+		writer.Write([]byte("3"))
+		close(written)
+	}()
+
+	// Then
+	select {
+	case <-written:
+		t.Fatal("write should block while the buffer is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(output.gate)
+	<-written
+	writer.Close()
+	AssertEquals(t, "0123", output.String())
+	AssertEquals(t, uint64(0), writer.Dropped())
+}
+
+func TestShouldSpillToDiskOnOverflow(t *testing.T) {
+	// Given
+	output := newGateWriter()
+	writer := fillAsyncWriter(output, async.OverflowSpill)
+
+	// When
+	for _, data := range []string{"3", "4", "5"} {
+		writer.Write([]byte(data))
+	}
+	close(output.gate)
+	writer.Flush()
+	writer.Write([]byte("6"))
+	writer.Close()
+
+	// Then
+	AssertEquals(t, "0123456", output.String())
+	AssertEquals(t, uint64(0), writer.Dropped())
+}
+
+// countingWriter counts the writes it receives.
+type countingWriter struct {
+	SafeBuffer
+	writes atomic.Int32
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes.Add(1)
+	return w.SafeBuffer.Write(p)
+}
+
+func TestShouldWriteEveryMessageSeparatelyByDefault(t *testing.T) {
+	// Given
+	output := &countingWriter{}
+	writer := async.NewAsyncWriter(output, 100)
+
+	// When
+	for range 10 {
+		writer.Write([]byte("x"))
+	}
+	writer.Close()
+
+	// Then
+	AssertEquals(t, int32(10), output.writes.Load())
+	AssertEquals(t, "xxxxxxxxxx", output.String())
+}
+
+func TestShouldCoalesceWritesWithinInterval(t *testing.T) {
+	// Given
+	output := &countingWriter{}
+	writer := async.NewAsyncWriterWithOptions(output, async.Options{MaxBatchBytes: 64 * 1024, FlushInterval: time.Hour})
+
+	// When
+	for range 10 {
+		writer.Write([]byte("x"))
+	}
+	writer.Flush()
+
+	// Then
+	AssertEquals(t, int32(1), output.writes.Load())
+	AssertEquals(t, "xxxxxxxxxx", output.String())
+	writer.Close()
+}
+
+func TestShouldWriteBatchWhenMaxBytesIsReached(t *testing.T) {
+	// Given
+	output := &countingWriter{}
+	writer := async.NewAsyncWriterWithOptions(output, async.Options{MaxBatchBytes: 4, FlushInterval: time.Hour})
+	defer writer.Close()
+
+	// When
+	writer.Write([]byte("ab"))
+	writer.Write([]byte("cd"))
+	deadline := time.Now().Add(time.Second)
+	for output.writes.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Then
+	AssertEquals(t, int32(1), output.writes.Load())
+	AssertEquals(t, "abcd", output.String())
+}
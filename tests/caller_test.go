@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// logThroughHelper logs through one helper function, as applications do with their own wrappers.
+func logThroughHelper(log *logger.Logger, msg string) {
+	log.Info(msg)
+}
+
+// currentLine returns the line of its caller.
+func currentLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line
+}
+
+func TestShouldReportCaller(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetReportCaller(true)
+
+	// When
+	log.Warn("any message")
+	line := currentLine() - 1
+
+	// Then
+	AssertEquals(t, fmt.Sprintf("WARN  AnyName tests/caller_test.go:%d: any message\n", line), output.String())
+}
+
+func TestShouldReportCallerOfEveryLoggingMethod(t *testing.T) {
+	// Given
+	var record *logger.Record
+
+	log := logger.New("AnyName")
+	log.SetLogLevel(logger.LevelAll)
+	log.SetReportCaller(true)
+	log.SetHandler(logger.HandlerFunc(func(_ io.Writer, r *logger.Record) {
+		record = r.Clone()
+	}))
+	check := func(line int) {
+		t.Helper()
+		frame := record.Caller()
+		AssertEquals(t, line, frame.Line)
+		AssertEquals(t, true, strings.HasSuffix(frame.Function, "TestShouldReportCallerOfEveryLoggingMethod"))
+	}
+
+	// When / Then
+	log.Log(logger.LevelInfo, "any")
+	check(currentLine() - 1)
+	log.Logf(logger.LevelInfo, "any")
+	check(currentLine() - 1)
+	log.Logw(logger.LevelInfo, "any")
+	check(currentLine() - 1)
+	log.Error("any")
+	check(currentLine() - 1)
+	log.Debugf("any")
+	check(currentLine() - 1)
+	log.With("k", "v").Tracew("any")
+	check(currentLine() - 1)
+}
+
+func TestShouldSkipHelperFrames(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetHandler(logger.NewJSONHandler(logger.ECSJSONKeys))
+	log.SetReportCaller(true)
+	log.SetCallerSkip(1)
+
+	// When
+	logThroughHelper(log, "any message")
+	line := currentLine() - 1
+
+	// Then
+	var entry map[string]any
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output.String(), err)
+	}
+	AssertEquals(t, fmt.Sprintf("tests/caller_test.go:%d", line), entry["caller"])
+	AssertEquals(t, true, strings.HasSuffix(entry["log.origin.function"].(string), "TestShouldSkipHelperFrames"))
+}
+
+func TestShouldReportCallerThroughDefaultLogDispatcher(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.DefaultLogDispatcher)
+	log.SetReportCaller(true)
+
+	// When
+	log.With("k", "v").Warn("any message")
+	line := currentLine() - 1
+
+	// Then
+	AssertEquals(t, fmt.Sprintf("WARN  AnyName tests/caller_test.go:%d: any message k=v\n", line), output.String())
+}
+
+func TestShouldNotReportCallerByDefault(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+
+	// When
+	log.Info("any message")
+
+	// Then
+	AssertEquals(t, "INFO  AnyName: any message\n", output.String())
+}
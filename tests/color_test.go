@@ -0,0 +1,92 @@
+package tests
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// handleColored formats a record with the handler and returns the output.
+func handleColored(handler logger.Handler, lv logger.Level) string {
+	var output bytes.Buffer
+	handler.Handle(&output, &logger.Record{
+		Time:       time.Date(2025, 4, 20, 15, 4, 5, 0, time.UTC),
+		Level:      lv,
+		Name:       "AnyName",
+		DateFormat: time.TimeOnly,
+		Args:       []any{"any message"},
+		Fields:     []logger.Field{{Key: "k", Value: "v"}},
+	})
+	return output.String()
+}
+
+func TestShouldColorTextOutput(t *testing.T) {
+	// Given
+	handler := logger.NewColorTextHandler(logger.ColorAlways)
+
+	// When
+	warn := handleColored(handler, logger.LevelWarn)
+	fatal := handleColored(handler, logger.LevelFatal)
+
+	// Then
+	AssertEquals(t, "\x1b[2m15:04:05\x1b[0m - \x1b[33mWARN\x1b[0m  \x1b[1mAnyName\x1b[0m: any message k=v\n", warn)
+	AssertEquals(t, "\x1b[2m15:04:05\x1b[0m - \x1b[1;31mFATAL\x1b[0m \x1b[1mAnyName\x1b[0m: any message k=v\n", fatal)
+}
+
+func TestShouldConfigureTextColors(t *testing.T) {
+	// Given
+	handler := &logger.ColorTextHandler{
+		Mode:      logger.ColorAlways,
+		Colors:    map[logger.Level]string{logger.LevelInfo: "34"},
+		TimeColor: "0",
+		NameColor: "4",
+	}
+
+	// When
+	output := handleColored(handler, logger.LevelInfo)
+
+	// Then
+	AssertEquals(t, "\x1b[0m15:04:05\x1b[0m - \x1b[34mINFO\x1b[0m  \x1b[4mAnyName\x1b[0m: any message k=v\n", output)
+}
+
+func TestShouldNotColorOutputThatIsNotATerminal(t *testing.T) {
+	// Given
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+
+	// When
+	output := handleColored(&logger.ColorTextHandler{}, logger.LevelError)
+
+	// Then
+	AssertEquals(t, handleColored(logger.TextHandler{}, logger.LevelError), output)
+	AssertEquals(t, "15:04:05 - ERROR AnyName: any message k=v\n", output)
+}
+
+func TestShouldHonourColorEnvironment(t *testing.T) {
+	// Given
+	handler := logger.NewColorTextHandler(logger.ColorAuto)
+
+	// When
+	t.Setenv("FORCE_COLOR", "1")
+	forced := handleColored(handler, logger.LevelError)
+
+	t.Setenv("NO_COLOR", "1")
+	disabled := handleColored(handler, logger.LevelError)
+
+	// Then
+	AssertEquals(t, "\x1b[2m15:04:05\x1b[0m - \x1b[31mERROR\x1b[0m \x1b[1mAnyName\x1b[0m: any message k=v\n", forced)
+	AssertEquals(t, "15:04:05 - ERROR AnyName: any message k=v\n", disabled)
+}
+
+func TestShouldNeverColorOutput(t *testing.T) {
+	// Given
+	t.Setenv("FORCE_COLOR", "1")
+
+	// When
+	output := handleColored(logger.NewColorTextHandler(logger.ColorNever), logger.LevelError)
+
+	// Then
+	AssertEquals(t, "15:04:05 - ERROR AnyName: any message k=v\n", output)
+}
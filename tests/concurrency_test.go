@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// Run with "go test -race" to detect unsynchronised accesses.
+
+func TestShouldReconfigureWhileLogging(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	var wg sync.WaitGroup
+	const goroutines, messages = 8, 200
+
+	log := logger.New("AnyName")
+	log.SetOutput(&output)
+	log.SetLogLevel(logger.LevelAll)
+
+	// When
+	for range goroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range messages {
+				log.Info("any message")
+				log.With("k", "v").Debugw("any message")
+				log.IsTraceEnabled()
+			}
+		}()
+	}
+
+	for range messages {
+		log.SetLogLevel(logger.LevelTrace)
+		log.SetDateFormat("15:04:05")
+		log.SetLogDispatcher(logger.DefaultLogDispatcher)
+		log.SetHandler(logger.TextHandler{})
+		log.SetOutput(&output)
+	}
+
+	wg.Wait()
+
+	// Then
+	AssertEquals(t, 2*goroutines*messages, strings.Count(output.String(), "\n"))
+}
+
+func TestShouldCreateLoggersWhileChangingDefaults(t *testing.T) {
+	// Given
+	var wg sync.WaitGroup
+	instances := make(chan *logger.Logger, 8)
+
+	defer logger.SetDefaultOutput(logger.DefaultWriter)
+	defer logger.SetDefaultLogLevel(logger.DefaultLogLevel)
+
+	// When
+	for range cap(instances) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			instances <- logger.GetInstance()
+			logger.New("AnyName").Info("any message")
+		}()
+	}
+
+	for range 100 {
+		logger.SetDefaultOutput(io.Discard)
+		logger.SetDefaultLogLevel(logger.LevelWarn)
+		logger.SetDefaultDateFormat(logger.DefaultDateFormat)
+		logger.SetDefaultHandler(logger.TextHandler{})
+	}
+
+	wg.Wait()
+	close(instances)
+
+	// Then
+	global := logger.GetInstance()
+	for instance := range instances {
+		AssertEquals(t, global, instance)
+	}
+}
@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldExitOnFatalAfterFlushingAndRunningHandlers(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	var events []string
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(UnformattedDispatcher)
+	log.SetAsyncOutput(&output, 10)
+	defer log.Close()
+
+	logger.SetExitOnFatal(true)
+	defer logger.SetExitOnFatal(false)
+	logger.SetExitFunc(func(code int) {
+		events = append(events, "exit:"+output.String())
+		AssertEquals(t, 1, code)
+	})
+	defer logger.SetExitFunc(nil)
+	logger.RegisterExitHandler(func() {
+		events = append(events, "handler")
+	})
+	logger.RegisterExitHandler(func() {
+		panic("should not prevent the exit")
+	})
+
+	// When
+	log.Fatal("any message")
+
+	// Then
+	AssertEquals(t, 2, len(events))
+	AssertEquals(t, "handler", events[0])
+	AssertEquals(t, "exit:any message", events[1])
+}
+
+func TestShouldNotRunExitHandlersAgainOnFatalFromHandler(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	handled, exits := 0, 0
+	active := true
+	defer func() { active = false }()
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(UnformattedDispatcher)
+	log.SetOutput(&output)
+
+	logger.SetExitOnFatal(true)
+	defer logger.SetExitOnFatal(false)
+	logger.SetExitFunc(func(int) {
+		exits++
+	})
+	defer logger.SetExitFunc(nil)
+	logger.RegisterExitHandler(func() {
+		if active {
+			handled++
+			log.Fatal("from handler")
+		}
+	})
+
+	// When
+	log.Fatal("any message")
+
+	// Then
+	AssertEquals(t, 1, handled)
+	AssertEquals(t, 2, exits)
+	AssertEquals(t, "any messagefrom handler", output.String())
+}
+
+func TestShouldNotExitOnFatalByDefault(t *testing.T) {
+	// Given
+	exited := false
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(UnformattedDispatcher)
+	log.SetOutput(&SafeBuffer{})
+
+	logger.SetExitFunc(func(int) {
+		exited = true
+	})
+	defer logger.SetExitFunc(nil)
+
+	// When
+	log.Fatal("any message")
+	log.Fatalf("any %s", "message")
+	log.Fatalw("any message")
+
+	// Then
+	AssertEquals(t, false, exited)
+}
+
+func TestShouldPanicAfterLogging(t *testing.T) {
+	// Given
+	var output SafeBuffer
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(UnformattedDispatcher)
+	log.SetOutput(&output)
+
+	// When
+	recovered := func() (value any) {
+		defer func() {
+			value = recover()
+		}()
+		log.Panicf("user %d failed", 42)
+		return nil
+	}()
+
+	// Then
+	AssertEquals(t, "user 42 failed", recovered)
+	AssertEquals(t, "user 42 failed", output.String())
+}
@@ -0,0 +1,65 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldLogKeyValuePairs(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("AnyDate")
+	log.SetOutput(&output)
+
+	// When
+	log.Infow("user logged in", "user_id", 42, "ip", "10.0.0.1", "agent", "any agent")
+
+	// Then
+	AssertEquals(t, "AnyDate - INFO  AnyName: user logged in user_id=42 ip=10.0.0.1 agent=\"any agent\"\n", output.String())
+}
+
+func TestShouldBindFieldsToChildLogger(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+
+	// When
+	child := log.With("request_id", "abc")
+	child.Warn("split", "test")
+	log.Warn("parent")
+
+	// Then
+	AssertEquals(t, "WARN  AnyName: split test request_id=abc\nWARN  AnyName: parent\n", output.String())
+	AssertEquals(t, 1, len(child.Fields()))
+	AssertEquals(t, 0, len(log.Fields()))
+}
+
+func TestShouldDispatchTypedFields(t *testing.T) {
+	// Given
+	var fields []logger.Field
+	var args []any
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(func(_ io.Writer, _ string, _ string, _ logger.Level, a ...any) {
+		args, fields = logger.SplitFields(a)
+	})
+
+	// When
+	log.With("user_id", 42).Errorw("failed", logger.Field{Key: "attempt", Value: 3}, "dangling")
+
+	// Then
+	AssertEquals(t, 1, len(args))
+	AssertEquals(t, "failed", args[0])
+	AssertEquals(t, 3, len(fields))
+	AssertEquals(t, logger.Field{Key: "user_id", Value: 42}, fields[0])
+	AssertEquals(t, logger.Field{Key: "attempt", Value: 3}, fields[1])
+	AssertEquals(t, logger.Field{Key: "!BADKEY", Value: "dangling"}, fields[2])
+}
@@ -0,0 +1,110 @@
+package tests
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// formatCounter counts how many times it is formatted.
+type formatCounter struct {
+	count int
+}
+
+func (c *formatCounter) String() string {
+	c.count++
+	return "counter"
+}
+
+func TestShouldLogFormattedMessage(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+
+	// When
+	log.With("k", "v").Warnf("%d items in %s", 3, "cart")
+
+	// Then
+	AssertEquals(t, "WARN  AnyName: 3 items in cart k=v\n", output.String())
+}
+
+func TestShouldSeparateFieldsFromTemplateWithoutArguments(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+
+	// When
+	log.With("k", "v").Infof("done")
+
+	// Then
+	AssertEquals(t, "INFO  AnyName: done k=v\n", output.String())
+}
+
+func TestShouldNotFormatDisabledLevels(t *testing.T) {
+	// Given
+	counter := &formatCounter{}
+
+	log := logger.New("AnyName")
+	log.SetOutput(io.Discard)
+	log.SetLogLevel(logger.LevelInfo)
+
+	// When
+	log.Debugf("value: %s", counter)
+	log.Tracef("value: %s", counter)
+	log.Logf(logger.LevelDebug, "value: %s", counter)
+	log.Infof("value: %s", counter)
+
+	// Then
+	AssertEquals(t, 1, counter.count)
+}
+
+func TestShouldPassTemplateAndArgumentsSeparately(t *testing.T) {
+	// Given
+	var record *logger.Record
+	var dispatched string
+
+	log := logger.New("AnyName")
+	log.SetHandler(logger.HandlerFunc(func(_ io.Writer, r *logger.Record) {
+		record = r.Clone()
+	}))
+
+	// When
+	log.Errorf("user %d failed", 42)
+	log.SetLogDispatcher(func(_ io.Writer, _ string, _ string, _ logger.Level, a ...any) {
+		dispatched = a[0].(string)
+	})
+	log.Errorf("user %d failed", 42)
+
+	// Then
+	AssertEquals(t, "user %d failed", record.Format)
+	AssertEquals(t, 1, len(record.Args))
+	AssertEquals(t, 42, record.Args[0])
+	AssertEquals(t, "user 42 failed", record.Message())
+	AssertEquals(t, "user 42 failed", dispatched)
+}
+
+func TestShouldWriteJSONTemplate(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	keys := logger.DefaultJSONKeys
+	keys.Template = "template"
+
+	log := logger.New("")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetHandler(logger.NewJSONHandler(keys))
+
+	// When
+	log.Infof("user %d logged in", 42)
+
+	// Then
+	AssertEquals(t, `{"level":"INFO","msg":"user 42 logged in","template":"user %d logged in"}`+"\n", output.String())
+}
@@ -0,0 +1,154 @@
+//go:build linux
+
+package tests
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+	"github.com/ecromaneli-golang/console/logger/journald"
+)
+
+// listenJournal creates a fake journald socket.
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("cannot listen on a unix datagram socket: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, path
+}
+
+// readJournalEntry reads an entry from the fake journald socket, from the
+// datagram or from the file descriptor sent with it.
+func readJournalEntry(t *testing.T, conn *net.UnixConn) map[string]string {
+	t.Helper()
+	buf := make([]byte, 1<<16)
+	oob := make([]byte, syscall.CmsgSpace(4))
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf[:n]
+	if oobn > 0 {
+		messages, _ := syscall.ParseSocketControlMessage(oob[:oobn])
+		fds, err := syscall.ParseUnixRights(&messages[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		file := os.NewFile(uintptr(fds[0]), "entry")
+		defer file.Close()
+		info, _ := file.Stat()
+		data = make([]byte, info.Size())
+		if _, err := file.ReadAt(data, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entry := map[string]string{}
+	for len(data) > 0 {
+		line, rest, _ := bytes.Cut(data, []byte{'\n'})
+		if name, value, ok := bytes.Cut(line, []byte{'='}); ok {
+			entry[string(name)] = string(value)
+			data = rest
+			continue
+		}
+		size := binary.LittleEndian.Uint64(rest)
+		entry[string(line)] = string(rest[8 : 8+size])
+		data = rest[8+size+1:]
+	}
+	return entry
+}
+
+func TestShouldSendJournalEntries(t *testing.T) {
+	// Given
+	listener, path := listenJournal(t)
+	writer, err := journald.Dial(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	log := logger.New("db.pool")
+	log.SetOutput(writer)
+	log.SetReportCaller(true)
+	log.SetHandler(journald.NewHandler(journald.Options{}))
+
+	// When
+	log.Warnw("slow query", "duration_ms", 1500, "sql", "SELECT *\nFROM t", "_trusted", true, "1st", "x")
+	line := currentLine() - 1
+
+	// Then
+	entry := readJournalEntry(t, listener)
+	AssertEquals(t, "slow query", entry["MESSAGE"])
+	AssertEquals(t, "4", entry["PRIORITY"])
+	AssertEquals(t, "db.pool", entry["SYSLOG_IDENTIFIER"])
+	AssertEquals(t, true, strings.HasSuffix(entry["CODE_FILE"], "tests/journald_test.go"))
+	AssertEquals(t, strconv.Itoa(line), entry["CODE_LINE"])
+	AssertEquals(t, "github.com/ecromaneli-golang/console/tests.TestShouldSendJournalEntries", entry["CODE_FUNC"])
+	AssertEquals(t, "1500", entry["DURATION_MS"])
+	AssertEquals(t, "SELECT *\nFROM t", entry["SQL"])
+	AssertEquals(t, "true", entry["TRUSTED"])
+	AssertEquals(t, "x", entry["ST"])
+}
+
+func TestShouldSetJournalIdentifier(t *testing.T) {
+	// Given
+	listener, path := listenJournal(t)
+	writer, err := journald.Dial(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	log := logger.New("db.pool")
+	log.SetOutput(writer)
+	log.SetHandler(journald.NewHandler(journald.Options{Identifier: "billing"}))
+
+	// When
+	log.Error("failed")
+
+	// Then
+	entry := readJournalEntry(t, listener)
+	AssertEquals(t, "3", entry["PRIORITY"])
+	AssertEquals(t, "billing", entry["SYSLOG_IDENTIFIER"])
+	AssertEquals(t, "db.pool", entry["LOGGER"])
+	AssertEquals(t, "", entry["CODE_FILE"])
+}
+
+func TestShouldSendLargeJournalEntriesThroughMemoryFile(t *testing.T) {
+	// Given
+	listener, path := listenJournal(t)
+	writer, err := journald.Dial(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	log := logger.New("AnyName")
+	log.SetOutput(writer)
+	log.SetHandler(journald.NewHandler(journald.Options{}))
+	message := strings.Repeat("x", 4<<20)
+
+	// When
+	log.Info(message)
+
+	// Then
+	entry := readJournalEntry(t, listener)
+	AssertEquals(t, len(message), len(entry["MESSAGE"]))
+	AssertEquals(t, "6", entry["PRIORITY"])
+}
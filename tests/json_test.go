@@ -0,0 +1,170 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldLogJSONLine(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.JSONLogDispatcher)
+
+	// When
+	log.Infow("user logged in", "user_id", 42, "ok", true, "err", errors.New("any error"))
+
+	// Then
+	AssertEquals(t, `{"level":"INFO","logger":"AnyName","msg":"user logged in","user_id":42,"ok":true,"err":"any error"}`+"\n", output.String())
+}
+
+func TestShouldEscapeJSONStrings(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	const message = "quote \" backslash \\ newline \n tab \t control \x01 invalid \xff unicode   ç"
+
+	log := logger.New("AnyName")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.JSONLogDispatcher)
+
+	// When
+	log.Warn(message, "split")
+
+	// Then
+	var entry map[string]any
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output.String(), err)
+	}
+	AssertEquals(t, "quote \" backslash \\ newline \n tab \t control \x01 invalid � unicode   ç split", entry["msg"])
+	AssertEquals(t, "WARN", entry["level"])
+	AssertEquals(t, true, entry["time"] != nil)
+}
+
+func TestShouldUseCustomJSONKeys(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("2006")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.NewJSONLogDispatcher(logger.JSONKeys{Time: "@timestamp", Level: "severity", Message: "message"}))
+
+	// When
+	log.Error("failed")
+
+	// Then
+	var entry map[string]any
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output.String(), err)
+	}
+	AssertEquals(t, 3, len(entry))
+	AssertEquals(t, "ERROR", entry["severity"])
+	AssertEquals(t, "failed", entry["message"])
+	AssertEquals(t, 4, len(entry["@timestamp"].(string)))
+}
+
+// nilError is an error whose Error method panics on a nil pointer.
+type nilError struct{ msg string }
+
+func (e *nilError) Error() string { return e.msg }
+
+// nilStringer is a fmt.Stringer whose String method panics on a nil pointer.
+type nilStringer struct{ name string }
+
+func (s *nilStringer) String() string { return s.name }
+
+// panicMarshaler is a json.Marshaler that always panics.
+type panicMarshaler struct{}
+
+func (panicMarshaler) MarshalJSON() ([]byte, error) { panic("any panic") }
+
+func (panicMarshaler) String() string { return "any marshaler" }
+
+func TestShouldLogNilPointersAndPanickingValuesAsJSON(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.JSONLogDispatcher)
+
+	// When
+	log.Infow("x", "at", (*time.Time)(nil), "err", (*nilError)(nil), "stringer", (*nilStringer)(nil), "marshaler", panicMarshaler{})
+
+	// Then
+	AssertEquals(t, `{"level":"INFO","logger":"AnyName","msg":"x","at":null,"err":null,"stringer":null,"marshaler":"any marshaler"}`+"\n", output.String())
+}
+
+func TestShouldWritePresetTimesAsRFC3339(t *testing.T) {
+	for _, keys := range []logger.JSONKeys{logger.ECSJSONKeys, logger.GCPJSONKeys} {
+		// Given
+		var output bytes.Buffer
+
+		log := logger.New("AnyName")
+		log.SetDateFormat(logger.DefaultDateFormat)
+		log.SetOutput(&output)
+		log.SetHandler(logger.NewJSONHandler(keys))
+
+		// When
+		log.Info("any message")
+
+		// Then
+		var entry map[string]any
+		if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+			t.Fatalf("invalid JSON %q: %v", output.String(), err)
+		}
+		_, err := time.Parse(time.RFC3339Nano, entry[keys.Time].(string))
+		AssertEquals(t, nil, err)
+	}
+}
+
+func TestShouldWriteGCPSeverities(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogLevel(logger.LevelAll)
+	log.SetHandler(logger.NewJSONHandler(logger.JSONKeys{Level: "severity", LevelName: logger.GCPJSONKeys.LevelName}))
+
+	// When
+	for _, lv := range []logger.Level{logger.LevelFatal, logger.LevelError, logger.LevelWarn, logger.Level(17), logger.LevelInfo, logger.LevelDebug, logger.LevelTrace} {
+		log.Log(lv)
+	}
+
+	// Then
+	AssertEquals(t, `{"severity":"CRITICAL"}
+{"severity":"ERROR"}
+{"severity":"WARNING"}
+{"severity":"NOTICE"}
+{"severity":"INFO"}
+{"severity":"DEBUG"}
+{"severity":"DEBUG"}
+`, output.String())
+}
+
+func TestShouldPrefixFieldsCollidingWithBuiltinKeys(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetHandler(logger.NewJSONHandler(logger.ECSJSONKeys))
+
+	// When
+	log.Infow("x", "message", "dup", "log.level", "dup", "user_id", 42)
+
+	// Then
+	AssertEquals(t, `{"log.level":"INFO","log.logger":"AnyName","message":"x","fields.message":"dup","fields.log.level":"dup","user_id":42}`+"\n", output.String())
+}
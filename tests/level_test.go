@@ -0,0 +1,189 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldParseLevels(t *testing.T) {
+	for str, expected := range map[string]logger.Level{
+		"info":      logger.LevelInfo,
+		"Warning":   logger.LevelWarn,
+		"err":       logger.LevelError,
+		"CRIT":      logger.LevelFatal,
+		"off":       logger.LevelOff,
+		"22":        logger.Level(22),
+		"level(17)": logger.Level(17),
+	} {
+		// When
+		lv, err := logger.ParseLevel(str)
+
+		// Then
+		AssertEquals(t, nil, err)
+		AssertEquals(t, expected, lv)
+	}
+}
+
+func TestShouldRejectUnknownLevels(t *testing.T) {
+	for _, str := range []string{"inof", "", "256", "-1", "LEVEL(", "LEVEL(x)"} {
+		// When
+		lv, err := logger.ParseLevel(str)
+
+		// Then
+		AssertEquals(t, logger.LevelOff, lv)
+		AssertEquals(t, `logger: unknown level "`+str+`"`, err.Error())
+	}
+}
+
+func TestShouldKeepLevelWhenSettingUnknownLevelString(t *testing.T) {
+	// Given
+	log := logger.New("AnyName")
+	log.SetLogLevel(logger.LevelWarn)
+
+	// When
+	log.SetLogLevelStr("inof")
+	err := log.SetLogLevelString("inof")
+
+	// Then
+	AssertEquals(t, `logger: unknown level "inof"`, err.Error())
+	AssertEquals(t, logger.LevelWarn, log.LogLevel())
+}
+
+func TestShouldSetLevelString(t *testing.T) {
+	// Given
+	log := logger.New("AnyName")
+
+	// When
+	err := log.SetLogLevelString("debug")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelDebug, log.LogLevel())
+}
+
+func TestShouldRepresentUnnamedLevels(t *testing.T) {
+	// Given
+	lv := logger.Level(22)
+
+	// When
+	str := lv.String()
+	parsed, err := logger.ParseLevel(str)
+
+	// Then
+	AssertEquals(t, "LEVEL(22)", str)
+	AssertEquals(t, nil, err)
+	AssertEquals(t, lv, parsed)
+}
+
+func TestShouldMarshalLevelsInConfigStructs(t *testing.T) {
+	// Given
+	type config struct {
+		Level    logger.Level            `json:"level"`
+		Loggers  map[string]logger.Level `json:"loggers"`
+		Fallback logger.Level            `json:"fallback"`
+	}
+	var cfg config
+
+	// When
+	err := json.Unmarshal([]byte(`{"level":"debug","loggers":{"db":"warning"},"fallback":25}`), &cfg)
+	data, _ := json.Marshal(cfg)
+	invalid := json.Unmarshal([]byte(`{"level":"inof"}`), &cfg)
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelDebug, cfg.Level)
+	AssertEquals(t, logger.LevelWarn, cfg.Loggers["db"])
+	AssertEquals(t, `{"level":"DEBUG","loggers":{"db":"WARN"},"fallback":"DEBUG"}`, string(data))
+	AssertEquals(t, `logger: unknown level "inof"`, invalid.Error())
+}
+
+func TestShouldKeepLevelOnJSONNull(t *testing.T) {
+	// Given
+	cfg := struct {
+		Level logger.Level `json:"level"`
+	}{Level: logger.LevelWarn}
+
+	// When
+	err := json.Unmarshal([]byte(`{"level":null}`), &cfg)
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelWarn, cfg.Level)
+}
+
+func TestShouldParseLevelFlags(t *testing.T) {
+	// Given
+	lv := logger.LevelInfo
+	flags := flag.NewFlagSet("any", flag.ContinueOnError)
+	flags.Var(&lv, "level", "log level")
+
+	// When
+	err := flags.Parse([]string{"-level", "trace"})
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelTrace, lv)
+	AssertEquals(t, "TRACE", flags.Lookup("level").Value.String())
+}
+
+func TestShouldRegisterCustomLevels(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	notice, audit := logger.Level(17), logger.Level(12)
+	defer logger.UnregisterLevel(notice)
+	defer logger.UnregisterLevel(audit)
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogLevel(logger.LevelInfo)
+
+	// When
+	err := logger.RegisterLevel(notice, "NOTICE", logger.LevelOptions{Aliases: []string{"NOTE"}})
+	logger.RegisterLevel(audit, "AUDIT", logger.LevelOptions{Color: "35"})
+	parsed, _ := logger.ParseLevel("note")
+
+	log.Log(notice, "noticed")
+	log.Log(audit, "audited")
+	log.Info("info")
+	log.Log(logger.Level(22), "ignored")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "NOTICE", notice.String())
+	AssertEquals(t, notice, parsed)
+	AssertEquals(t, "NOTICE AnyName: noticed\nAUDIT AnyName: audited\nINFO  AnyName: info\n", output.String())
+}
+
+func TestShouldUnregisterCustomLevels(t *testing.T) {
+	// Given
+	lv := logger.Level(16)
+	logger.RegisterLevel(lv, "REMOVED", logger.LevelOptions{Aliases: []string{"GONE"}})
+
+	// When
+	err := logger.UnregisterLevel(lv)
+	_, parseErr := logger.ParseLevel("gone")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "LEVEL(16)", lv.String())
+	AssertEquals(t, `logger: unknown level "gone"`, parseErr.Error())
+	AssertEquals(t, "logger: cannot unregister predefined level INFO", logger.UnregisterLevel(logger.LevelInfo).Error())
+}
+
+func TestShouldRejectInvalidLevelNames(t *testing.T) {
+	// When
+	invalid := logger.RegisterLevel(logger.Level(18), "1ST", logger.LevelOptions{})
+	spaced := logger.RegisterLevel(logger.Level(18), "MY LEVEL", logger.LevelOptions{})
+	used := logger.RegisterLevel(logger.Level(18), "SEVERE", logger.LevelOptions{Aliases: []string{"err"}})
+
+	// Then
+	AssertEquals(t, `logger: invalid level name "1ST"`, invalid.Error())
+	AssertEquals(t, `logger: invalid level name "MY LEVEL"`, spaced.Error())
+	AssertEquals(t, `logger: level name "err" is already used by ERROR`, used.Error())
+	AssertEquals(t, "LEVEL(18)", logger.Level(18).String())
+}
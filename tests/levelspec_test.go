@@ -0,0 +1,96 @@
+package tests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldApplyLevelSpecToLoggers(t *testing.T) {
+	// Given
+	defer logger.SetLogLevelSpec("")
+	defer logger.SetDefaultLogLevel(logger.DefaultLogLevel)
+
+	db := logger.New("spec.db")
+	client := logger.New("spec.http.client")
+	other := logger.New("spec.other")
+
+	// When
+	err := logger.SetLogLevelSpec(" warn, spec.db=debug ,spec.http.client=TRACE")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelWarn, logger.DefaultLogLevel)
+	AssertEquals(t, logger.LevelDebug, db.LogLevel())
+	AssertEquals(t, logger.LevelTrace, client.LogLevel())
+	AssertEquals(t, logger.LevelWarn, other.LogLevel())
+	AssertEquals(t, logger.LevelDebug, logger.New("spec.db.pool").LogLevel())
+}
+
+func TestShouldReplacePreviousLevelSpec(t *testing.T) {
+	// Given
+	defer logger.SetLogLevelSpec("")
+
+	db := logger.New("respec.db")
+	client := logger.New("respec.client")
+	logger.SetLogLevelSpec("respec.db=debug,respec.client=fatal")
+
+	// When
+	err := logger.SetLogLevelSpec("respec.client=error")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.DefaultLogLevel, db.LogLevel())
+	AssertEquals(t, logger.LevelError, client.LogLevel())
+}
+
+func TestShouldRestoreDefaultLevelOmittedByNextSpec(t *testing.T) {
+	// Given
+	defer logger.SetLogLevelSpec("")
+	defer logger.SetDefaultLogLevel(logger.DefaultLogLevel)
+
+	logger.SetDefaultLogLevel(logger.LevelInfo)
+	logger.SetLogLevelSpec("debug")
+	logger.SetLogLevelSpec("trace")
+
+	// When
+	err := logger.SetLogLevelSpec("respec.db=info")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelInfo, logger.DefaultLogLevel)
+	AssertEquals(t, logger.LevelInfo, logger.New("respec.other").LogLevel())
+}
+
+func TestShouldRejectInvalidLevelSpec(t *testing.T) {
+	// Given
+	log := logger.New("invalid.db")
+
+	// When
+	err := logger.SetLogLevelSpec("invalid.db=debug,invalid.http=verbose,=info,invalid.client=")
+
+	// Then
+	AssertEquals(t, logger.DefaultLogLevel, log.LogLevel())
+	AssertEquals(t, `logger: invalid level spec entry "invalid.http=verbose": unknown level "verbose"`+"\n"+
+		`logger: invalid level spec entry "=info": missing logger name`+"\n"+
+		`logger: invalid level spec entry "invalid.client=": missing level`, err.Error())
+}
+
+func TestShouldReadLevelSpecFromEnv(t *testing.T) {
+	// Given
+	defer logger.SetLogLevelSpec("")
+	log := logger.New("env.db")
+
+	// When
+	t.Setenv("LOG_LEVEL", "env.db=trace")
+	err := logger.SetLogLevelSpecFromEnv("LOG_LEVEL")
+
+	t.Setenv("LOG_LEVEL", "env.db=loud")
+	invalid := logger.SetLogLevelSpecFromEnv("LOG_LEVEL")
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, logger.LevelTrace, log.LogLevel())
+	AssertEquals(t, true, strings.HasPrefix(invalid.Error(), "LOG_LEVEL: logger: invalid level spec entry"))
+}
@@ -2,6 +2,8 @@ package tests
 
 import (
 	"bytes"
+	"io"
+	"os"
 	"strconv"
 	"testing"
 
@@ -18,11 +20,50 @@ func BenchmarkDefaultLogDispatcher(b *testing.B) {
 	message := "This is a benchmark test message"
 
 	// When
+	b.ReportAllocs()
 	for i := 0; b.Loop(); i++ {
 		logger.DefaultLogDispatcher(&output, dateFormat, name, level, strconv.Itoa(i)+" - "+message)
 	}
 }
 
+func BenchmarkDefaultLogDispatcherStaticMessage(b *testing.B) {
+	// Given
+	dateFormat := "2006-01-02 15:04:05.000 Z07:00"
+	name := "BenchmarkLogger"
+	level := logger.LevelInfo
+
+	// When
+	b.ReportAllocs()
+	for b.Loop() {
+		logger.DefaultLogDispatcher(io.Discard, dateFormat, name, level, "This is a benchmark test message")
+	}
+}
+
+func BenchmarkLoggerInfo(b *testing.B) {
+	// Given
+	log := logger.New("BenchmarkLogger")
+	log.SetOutput(io.Discard)
+
+	// When
+	b.ReportAllocs()
+	for b.Loop() {
+		log.Info("This is a benchmark test message")
+	}
+}
+
+func BenchmarkLoggerInfoAsync(b *testing.B) {
+	// Given
+	log := logger.New("BenchmarkLogger")
+	log.SetAsyncOutput(io.Discard, 1000)
+	defer log.Close()
+
+	// When
+	b.ReportAllocs()
+	for b.Loop() {
+		log.Info("This is a benchmark test message")
+	}
+}
+
 func BenchmarkAsyncLogDispatcher(b *testing.B) {
 	// Given
 	var output bytes.Buffer
@@ -33,7 +74,44 @@ func BenchmarkAsyncLogDispatcher(b *testing.B) {
 	message := "This is a benchmark test message"
 
 	// When
+	b.ReportAllocs()
 	for i := 0; b.Loop(); i++ {
 		logger.DefaultLogDispatcher(asyncWriter, dateFormat, name, level, strconv.Itoa(i)+" - "+message)
 	}
 }
+
+func BenchmarkAsyncFileOutput(b *testing.B) {
+	for _, bench := range []struct {
+		name          string
+		maxBatchBytes int
+	}{
+		{"Unbatched", 0},
+		{"Batched", 64 * 1024},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			// Given
+			output, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer output.Close()
+
+			asyncWriter := async.NewAsyncWriterWithOptions(output, async.Options{
+				BufferSize:    1000,
+				Overflow:      async.OverflowBlock,
+				MaxBatchBytes: bench.maxBatchBytes,
+			})
+			dateFormat := "2006-01-02 15:04:05.000 Z07:00"
+			name := "BenchmarkLogger"
+			level := logger.LevelInfo
+			message := "This is a benchmark test message"
+
+			// When
+			b.ReportAllocs()
+			for i := 0; b.Loop(); i++ {
+				logger.DefaultLogDispatcher(asyncWriter, dateFormat, name, level, strconv.Itoa(i)+" - "+message)
+			}
+			asyncWriter.Close()
+		})
+	}
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"bytes"
+	"io"
 	"testing"
 
 	"github.com/ecromaneli-golang/console/logger"
@@ -121,6 +122,18 @@ func TestShouldUseDefaultFormatting(t *testing.T) {
 	AssertEquals(t, "AnyDate - WARN  AnyName: split test 1 2 3\n", output.String())
 }
 
+func TestShouldKeepDefaultDispatcherInSyncWithDefaultHandler(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	logger.SetDefaultHandler(logger.TextHandler{})
+
+	// When
+	logger.DefaultDispatcher(&output, "", "AnyName", logger.LevelInfo, "any message")
+
+	// Then
+	AssertEquals(t, "INFO  AnyName: any message\n", output.String())
+}
+
 func TestShouldLogAsync(t *testing.T) {
 	// Given
 	const anyMessage = "any message"
@@ -165,3 +178,41 @@ func TestShouldLogAsyncWithBuffer(t *testing.T) {
 	// Then
 	AssertEquals(t, "1234567890", output.String())
 }
+
+func TestShouldLogAsyncAfterFlush(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	log := logger.New("AnyName")
+	log.SetLogLevelStr("fatal")
+	log.SetAsyncOutput(&output, 10)
+	log.SetLogDispatcher(UnformattedDispatcher)
+
+	// When
+	log.Fatal("1")
+	log.Flush()
+	log.Fatal("2")
+	log.Close()
+	log.Fatal("3")
+
+	// Then
+	AssertEquals(t, "12", output.String())
+}
+
+func TestShouldNotAllocateForStringMessages(t *testing.T) {
+	if raceEnabled {
+		t.Skip("sync.Pool drops items when the race detector is enabled")
+	}
+
+	// Given
+	log := logger.New("AnyName")
+	log.SetOutput(io.Discard)
+
+	// When
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Info("any message")
+		log.Warn("split", "test")
+	})
+
+	// Then
+	AssertEquals(t, 0.0, allocs)
+}
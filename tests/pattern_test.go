@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// handlePattern formats a record with a pattern layout and returns the output.
+func handlePattern(t *testing.T, layout string, r *logger.Record) string {
+	t.Helper()
+	handler, err := logger.NewPatternHandler(layout)
+	if err != nil {
+		t.Fatalf("invalid layout %q: %v", layout, err)
+	}
+
+	var output bytes.Buffer
+	handler.Handle(&output, r)
+	return output.String()
+}
+
+func patternRecord() *logger.Record {
+	return &logger.Record{
+		Time:       time.Date(2025, 4, 20, 15, 4, 5, 0, time.UTC),
+		Level:      logger.LevelWarn,
+		Name:       "com.example.db.pool",
+		DateFormat: time.DateOnly,
+		Args:       []any{"any message"},
+		Fields:     []logger.Field{{Key: "user_id", Value: 42}, {Key: "ip", Value: "10.0.0.1"}},
+	}
+}
+
+func TestShouldFormatWithPatternLayout(t *testing.T) {
+	// When
+	output := handlePattern(t, "%d{15:04:05} [%-5level] %logger{15}: %msg %fields%n", patternRecord())
+
+	// Then
+	AssertEquals(t, "15:04:05 [WARN ] c.e.db.pool: any message user_id=42 ip=10.0.0.1\n", output)
+}
+
+func TestShouldUseLoggerDateFormat(t *testing.T) {
+	// Given
+	r := patternRecord()
+
+	// When
+	withFormat := handlePattern(t, "%d|%date", r)
+	r.DateFormat = ""
+	withoutFormat := handlePattern(t, "%d|%date", r)
+
+	// Then
+	AssertEquals(t, "2025-04-20|2025-04-20", withFormat)
+	AssertEquals(t, "|", withoutFormat)
+}
+
+func TestShouldPadAndTruncatePatternConversions(t *testing.T) {
+	// Given
+	r := patternRecord()
+
+	// When
+	output := handlePattern(t, "[%7p][%-7p][%.2p][%.-2p][%3.3p][%5m]", r)
+
+	// Then
+	AssertEquals(t, "[   WARN][WARN   ][RN][WA][ARN][any message]", output)
+}
+
+func TestShouldAbbreviateLoggerNames(t *testing.T) {
+	// Given
+	r := patternRecord()
+
+	// When
+	output := handlePattern(t, "%c{0}|%c{10}|%c{15}|%c{100}|%c", r)
+
+	// Then
+	AssertEquals(t, "pool|c.e.d.pool|c.e.db.pool|com.example.db.pool|com.example.db.pool", output)
+}
+
+func TestShouldFormatPatternFieldsAndLiterals(t *testing.T) {
+	// When
+	output := handlePattern(t, "100%% user=%X{user_id} missing=%kv{none}", patternRecord())
+
+	// Then
+	AssertEquals(t, "100% user=42 missing=", output)
+}
+
+func TestShouldFormatPatternCallSite(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	handler, _ := logger.NewPatternHandler("%file:%line %M %caller %gid|%msg")
+
+	log := logger.New("AnyName")
+	log.SetOutput(&output)
+	log.SetReportCaller(true)
+	log.SetHandler(handler)
+
+	// When
+	log.Info("any message")
+	line := strconv.Itoa(currentLine() - 1)
+
+	// Then
+	prefix := "pattern_test.go:" + line + " github.com/ecromaneli-golang/console/tests.TestShouldFormatPatternCallSite tests/pattern_test.go:" + line + " "
+	gid, msg, _ := strings.Cut(strings.TrimPrefix(output.String(), prefix), "|")
+	_, err := strconv.Atoi(gid)
+	AssertEquals(t, true, strings.HasPrefix(output.String(), prefix))
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "any message", msg)
+}
+
+func TestShouldDispatchWithPatternLayout(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+	dispatcher, _ := logger.NewPatternLogDispatcher("%-5level %logger: %msg %fields%n")
+
+	log := logger.New("AnyName")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(dispatcher)
+
+	// When
+	log.Warnw("any message", "k", "v")
+
+	// Then
+	AssertEquals(t, "WARN  AnyName: any message k=v\n", output.String())
+}
+
+func TestShouldRejectInvalidPatternLayouts(t *testing.T) {
+	for layout, expected := range map[string]string{
+		"%msg %foo":      `logger: invalid pattern "%msg %foo" at offset 5: unknown conversion word "foo"`,
+		"%d{15:04":       `logger: invalid pattern "%d{15:04" at offset 0: unclosed option of %d`,
+		"%-5":            `logger: invalid pattern "%-5" at offset 0: missing conversion word`,
+		"%.level":        `logger: invalid pattern "%.level" at offset 0: missing maximum width`,
+		"%logger{short}": `logger: invalid pattern "%logger{short}" at offset 0: invalid length "short" of %logger`,
+	} {
+		// When
+		_, err := logger.NewPatternHandler(layout)
+
+		// Then
+		AssertEquals(t, expected, err.Error())
+	}
+}
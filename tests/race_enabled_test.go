@@ -0,0 +1,6 @@
+//go:build race
+
+package tests
+
+// raceEnabled reports whether the race detector is enabled, which makes sync.Pool drop items.
+const raceEnabled = true
@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldPassRecordToHandler(t *testing.T) {
+	// Given
+	var record *logger.Record
+	before := time.Now()
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("AnyDate")
+	log.SetHandler(logger.HandlerFunc(func(_ io.Writer, r *logger.Record) {
+		record = r.Clone()
+	}))
+
+	// When
+	log.With("user_id", 42).Warnw("any message", "attempt", 3)
+
+	// Then
+	AssertEquals(t, logger.LevelWarn, record.Level)
+	AssertEquals(t, "AnyName", record.Name)
+	AssertEquals(t, "AnyDate", record.DateFormat)
+	AssertEquals(t, "any message", record.Message())
+	AssertEquals(t, 2, len(record.Fields))
+	AssertEquals(t, logger.Field{Key: "user_id", Value: 42}, record.Fields[0])
+	AssertEquals(t, logger.Field{Key: "attempt", Value: 3}, record.Fields[1])
+	AssertEquals(t, false, record.Time.Before(before))
+}
+
+func TestShouldAdaptHandlerToDispatcher(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetHandler(logger.TextHandler{})
+
+	// When
+	log.Dispatcher()(&output, "", "AnyName", logger.LevelInfo, "split", logger.Field{Key: "k", Value: "v"}, "test")
+
+	// Then
+	AssertEquals(t, "INFO  AnyName: split test k=v\n", output.String())
+}
+
+func TestShouldNotReuseArgumentsKeptByDispatcher(t *testing.T) {
+	// Given
+	var kept [][]any
+
+	log := logger.New("AnyName")
+	log.SetLogDispatcher(func(_ io.Writer, _ string, _ string, _ logger.Level, a ...any) {
+		kept = append(kept, a)
+	})
+
+	// When
+	log.Info("first")
+	log.Info("second")
+
+	// Then
+	AssertEquals(t, "[[first] [second]]", fmt.Sprint(kept))
+}
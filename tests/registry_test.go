@@ -0,0 +1,140 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldRegisterLoggersByDottedName(t *testing.T) {
+	// When
+	pool := logger.Get("registry.db.pool")
+
+	// Then
+	AssertEquals(t, pool, logger.Get("registry.db.pool"))
+	AssertEquals(t, logger.Get("registry.db"), pool.Parent())
+	AssertEquals(t, logger.Get("registry"), pool.Parent().Parent())
+	AssertEquals(t, logger.Get(""), pool.Parent().Parent().Parent())
+	AssertEquals(t, (*logger.Logger)(nil), logger.Get("").Parent())
+	AssertEquals(t, logger.Get("registry.db"), logger.New("registry.db").Parent())
+}
+
+func TestShouldInheritSettingsFromParent(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	parent := logger.Get("inherit")
+	parent.SetOutput(&output)
+	parent.SetDateFormat("")
+	parent.SetLogLevel(logger.LevelWarn)
+
+	log := logger.New("inherit.child")
+
+	// When
+	log.Info("ignored")
+	parent.SetLogLevel(logger.LevelDebug)
+	log.Debug("enabled by the parent")
+
+	// Then
+	AssertEquals(t, "DEBUG inherit.child: enabled by the parent\n", output.String())
+}
+
+func TestShouldNotInheritOverriddenSettings(t *testing.T) {
+	// Given
+	var parentOutput, childOutput bytes.Buffer
+
+	parent := logger.Get("override")
+	parent.SetOutput(&parentOutput)
+	parent.SetDateFormat("")
+	parent.SetLogLevel(logger.LevelAll)
+
+	child := logger.Get("override.child")
+	child.SetOutput(&childOutput)
+	child.SetLogLevel(logger.LevelError)
+
+	// When
+	parent.SetLogLevel(logger.LevelOff)
+	child.Warn("ignored")
+	child.Error("child")
+	parent.Error("ignored")
+
+	// Then
+	AssertEquals(t, "", parentOutput.String())
+	AssertEquals(t, "ERROR override.child: child\n", childOutput.String())
+}
+
+func TestShouldApplyDefaultsToExistingLoggers(t *testing.T) {
+	// Given
+	defer logger.SetDefaultLogLevel(logger.DefaultLogLevel)
+	logger.SetDefaultLogLevel(logger.LevelError)
+	log := logger.New("defaults")
+
+	// When
+	logger.SetDefaultLogLevel(logger.LevelTrace)
+
+	// Then
+	AssertEquals(t, true, log.IsTraceEnabled())
+}
+
+func TestShouldCreateNamedChildren(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("named").With("request_id", "abc")
+	log.SetOutput(&output)
+	log.SetDateFormat("")
+
+	// When
+	child := log.Named("sub")
+	child.Warn("any message")
+
+	// Then
+	AssertEquals(t, logger.Get("named.sub"), logger.Get("named").Named("sub"))
+	AssertEquals(t, logger.Get("named"), logger.Get("").Named("named"))
+	AssertEquals(t, log, child.Parent())
+	AssertEquals(t, "WARN  named.sub: any message request_id=abc\n", output.String())
+}
+
+func TestShouldInheritFromLoggerRegisteredAfterNew(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	// The registry outlives the test, so every run uses names of its own
+	name := fmt.Sprintf("late%d", time.Now().UnixNano())
+	logger.Get(name).SetLogLevel(logger.LevelInfo)
+	log := logger.New(name + ".registered.child")
+	log.SetOutput(&output)
+	log.SetDateFormat("")
+
+	// When
+	log.Debug("ignored")
+	logger.Get(name + ".registered").SetLogLevel(logger.LevelDebug)
+	log.Debug("enabled by the registered logger")
+
+	// Then
+	AssertEquals(t, logger.Get(name+".registered"), log.Parent())
+	AssertEquals(t, "DEBUG "+name+".registered.child: enabled by the registered logger\n", output.String())
+}
+
+func TestShouldNotCloseInheritedOutput(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	name := fmt.Sprintf("shared%d", time.Now().UnixNano())
+	parent := logger.Get(name)
+	parent.SetDateFormat("")
+	parent.SetAsyncOutput(&output, 10)
+	defer parent.Close()
+
+	// When
+	logger.New(name + ".child").Close()
+	logger.New(name + ".child").SetSync()
+	parent.Warn("still logged")
+	parent.Flush()
+
+	// Then
+	AssertEquals(t, "WARN  "+name+": still logged\n", output.String())
+}
@@ -0,0 +1,59 @@
+//go:build unix || windows
+
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger/rotate"
+)
+
+func TestShouldReopenFileOnSignal(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writer, _ := rotate.NewFileWriter(path, rotate.Options{})
+	defer writer.Close()
+
+	stop := writer.ReopenOnSignal()
+	defer stop()
+
+	writer.Write([]byte("before\n"))
+	os.Rename(path, path+".1")
+
+	// When
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("cannot send SIGHUP: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for _, err := os.Stat(path); err != nil && time.Now().Before(deadline); _, err = os.Stat(path) {
+		time.Sleep(time.Millisecond)
+	}
+	writer.Write([]byte("after\n"))
+
+	// Then
+	AssertEquals(t, "before\n", readFile(t, path+".1"))
+	AssertEquals(t, "after\n", readFile(t, path))
+}
+
+func TestShouldNotReopenOnSignalAfterClose(t *testing.T) {
+	// Given
+	writer, _ := rotate.NewFileWriter(filepath.Join(t.TempDir(), "app.log"), rotate.Options{})
+	writer.Close()
+
+	before := runtime.NumGoroutine()
+
+	// When
+	stop := writer.ReopenOnSignal()
+
+	// Then
+	AssertEquals(t, true, runtime.NumGoroutine() <= before)
+	stop()
+}
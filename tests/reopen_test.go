@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+	"github.com/ecromaneli-golang/console/logger/rotate"
+)
+
+func TestShouldReopenRenamedFile(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	writer, _ := rotate.NewFileWriter(path, rotate.Options{})
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetAsyncOutput(writer, 10)
+
+	// When
+	log.Warn("before")
+	log.Flush()
+	os.Rename(path, path+".1")
+	log.Warn("after rename")
+	log.Flush()
+	err := writer.Reopen()
+	log.Warn("after reopen")
+	log.Close()
+	writer.Close()
+
+	// Then
+	AssertEquals(t, nil, err)
+	AssertEquals(t, "WARN  AnyName: before\nWARN  AnyName: after rename\n", readFile(t, path+".1"))
+	AssertEquals(t, "WARN  AnyName: after reopen\n", readFile(t, path))
+}
+
+func TestShouldNotReopenClosedFileWriter(t *testing.T) {
+	// Given
+	writer, _ := rotate.NewFileWriter(filepath.Join(t.TempDir(), "app.log"), rotate.Options{})
+
+	// When
+	writer.Close()
+
+	// Then
+	AssertEquals(t, rotate.ErrClosed, writer.Reopen())
+}
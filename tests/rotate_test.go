@@ -0,0 +1,216 @@
+package tests
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger/async"
+	"github.com/ecromaneli-golang/console/logger/rotate"
+)
+
+// fakeClock is a clock that only moves when set, for the rotation by time.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// readFile returns the content of the file, decompressing it if it is gzipped.
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		if r, err = gzip.NewReader(f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// listFiles returns the names of the files in the directory, sorted.
+func listFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestShouldRotateFileBySize(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2025, 4, 20, 15, 4, 5, 0, time.Local)}
+	writer, err := rotate.NewFileWriter(filepath.Join(dir, "app.log"), rotate.Options{MaxSize: 20, Now: clock.Now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// When
+	writer.Write([]byte("line 0001\n"))
+	writer.Write([]byte("line 0002\n"))
+	writer.Write([]byte("line 0003\n"))
+	writer.Write([]byte("a line longer than the maximum size\n"))
+	writer.Close()
+
+	// Then
+	AssertEquals(t, "[app-2025-04-20T15-04-05.000.log app-2025-04-20T15-04-05.001.log app.log]", fmt.Sprint(listFiles(t, dir)))
+	AssertEquals(t, "line 0001\nline 0002\n", readFile(t, filepath.Join(dir, "app-2025-04-20T15-04-05.000.log")))
+	AssertEquals(t, "line 0003\n", readFile(t, filepath.Join(dir, "app-2025-04-20T15-04-05.001.log")))
+	AssertEquals(t, "a line longer than the maximum size\n", readFile(t, filepath.Join(dir, "app.log")))
+}
+
+func TestShouldRotateFileByTime(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2025, 4, 20, 10, 59, 0, 0, time.Local)}
+	writer, _ := rotate.NewFileWriter(filepath.Join(dir, "app.log"), rotate.Options{Interval: rotate.IntervalHourly, Now: clock.Now})
+
+	// When
+	writer.Write([]byte("10:59\n"))
+	clock.Set(time.Date(2025, 4, 20, 11, 0, 0, 0, time.Local))
+	writer.Write([]byte("11:00\n"))
+	writer.Write([]byte("11:00 again\n"))
+	writer.Close()
+
+	// Then
+	AssertEquals(t, "[app-2025-04-20T11-00-00.000.log app.log]", fmt.Sprint(listFiles(t, dir)))
+	AssertEquals(t, "10:59\n", readFile(t, filepath.Join(dir, "app-2025-04-20T11-00-00.000.log")))
+	AssertEquals(t, "11:00\n11:00 again\n", readFile(t, filepath.Join(dir, "app.log")))
+}
+
+func TestShouldKeepMaxBackupsCompressed(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2025, 4, 20, 0, 0, 0, 0, time.Local)}
+	writer, _ := rotate.NewFileWriter(filepath.Join(dir, "app"), rotate.Options{MaxBackups: 2, Compress: true, Now: clock.Now})
+
+	// When
+	for day := range 4 {
+		clock.Set(time.Date(2025, 4, 20+day, 0, 0, 0, 0, time.Local))
+		fmt.Fprintf(writer, "day %d\n", day)
+		writer.Rotate()
+	}
+	writer.Close()
+
+	// Then
+	AssertEquals(t, "[app app-2025-04-22T00-00-00.000.gz app-2025-04-23T00-00-00.000.gz]", fmt.Sprint(listFiles(t, dir)))
+	AssertEquals(t, "day 2\n", readFile(t, filepath.Join(dir, "app-2025-04-22T00-00-00.000.gz")))
+	AssertEquals(t, "", readFile(t, filepath.Join(dir, "app")))
+}
+
+func TestShouldRemoveBackupsOlderThanMaxAge(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2025, 4, 20, 0, 0, 0, 0, time.Local)}
+	writer, _ := rotate.NewFileWriter(filepath.Join(dir, "app.log"), rotate.Options{Interval: rotate.IntervalDaily, MaxAge: 48 * time.Hour, Now: clock.Now})
+
+	// When
+	for day := range 5 {
+		clock.Set(time.Date(2025, 4, 20+day, 12, 0, 0, 0, time.Local))
+		fmt.Fprintf(writer, "day %d\n", day)
+	}
+	writer.Close()
+
+	// Then
+	AssertEquals(t, "[app-2025-04-22T12-00-00.000.log app-2025-04-23T12-00-00.000.log app-2025-04-24T12-00-00.000.log app.log]", fmt.Sprint(listFiles(t, dir)))
+	AssertEquals(t, "day 4\n", readFile(t, filepath.Join(dir, "app.log")))
+}
+
+func TestShouldNotLoseWritesWhenRotatingBehindAsyncWriter(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	writer, _ := rotate.NewFileWriter(filepath.Join(dir, "app.log"), rotate.Options{MaxSize: 1024})
+	asyncWriter := async.NewAsyncWriter(writer, 16)
+
+	// When
+	var wg sync.WaitGroup
+	for g := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 250 {
+				fmt.Fprintf(asyncWriter, "goroutine %d line %03d\n", g, i)
+			}
+		}()
+	}
+	wg.Wait()
+	asyncWriter.Close()
+	writer.Close()
+
+	// Then
+	var lines []string
+	for _, name := range listFiles(t, dir) {
+		lines = append(lines, strings.Split(strings.TrimSuffix(readFile(t, filepath.Join(dir, name)), "\n"), "\n")...)
+	}
+	AssertEquals(t, 1000, len(lines))
+	AssertEquals(t, true, len(listFiles(t, dir)) > 10)
+}
+
+func TestShouldReturnErrorWhenWritingToClosedFileWriter(t *testing.T) {
+	// Given
+	writer, _ := rotate.NewFileWriter(filepath.Join(t.TempDir(), "app.log"), rotate.Options{})
+
+	// When
+	closeErr := writer.Close()
+	_, err := writer.Write([]byte("any"))
+
+	// Then
+	AssertEquals(t, nil, closeErr)
+	AssertEquals(t, rotate.ErrClosed, err)
+	AssertEquals(t, rotate.ErrClosed, writer.Rotate())
+}
+
+func TestShouldReturnErrorWhenBackupNameIsInvalid(t *testing.T) {
+	// Given
+	dir := t.TempDir()
+	name := strings.Repeat("a", 240)
+	writer, err := rotate.NewFileWriter(filepath.Join(dir, name+".log"), rotate.Options{MaxSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	// When
+	_, firstErr := writer.Write([]byte("line 0001\n"))
+	_, secondErr := writer.Write([]byte("line 0002\n"))
+
+	// Then
+	AssertEquals(t, nil, firstErr)
+	AssertEquals(t, true, secondErr != nil)
+	AssertEquals(t, "line 0001\n", readFile(t, filepath.Join(dir, name+".log")))
+}
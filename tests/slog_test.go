@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"bytes"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+func TestShouldRoundTripSlogLevels(t *testing.T) {
+	// Given
+	expected := map[logger.Level]slog.Level{
+		logger.LevelOff:   logger.SlogLevelOff,
+		logger.LevelFatal: logger.SlogLevelFatal,
+		logger.LevelError: slog.LevelError,
+		logger.LevelWarn:  slog.LevelWarn,
+		logger.LevelInfo:  slog.LevelInfo,
+		logger.LevelDebug: slog.LevelDebug,
+		logger.LevelTrace: logger.SlogLevelTrace,
+		logger.LevelAll:   logger.SlogLevelAll,
+	}
+
+	for level, slogLevel := range expected {
+		// When
+		converted := logger.ToSlogLevel(level)
+
+		// Then
+		AssertEquals(t, slogLevel, converted)
+		AssertEquals(t, level, logger.FromSlogLevel(converted))
+	}
+
+	AssertEquals(t, logger.Level(1), logger.FromSlogLevel(slog.Level(1000)))
+	AssertEquals(t, logger.Level(254), logger.FromSlogLevel(slog.Level(-1000)))
+	AssertEquals(t, logger.Level(1), logger.FromSlogLevel(slog.Level(math.MaxInt-1)))
+	AssertEquals(t, logger.Level(254), logger.FromSlogLevel(slog.Level(math.MinInt+1)))
+}
+
+func TestShouldLogSlogRecordsThroughLogger(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogLevel(logger.LevelInfo)
+
+	slogger := slog.New(logger.NewSlogHandler(log))
+
+	// When
+	slogger.Debug("ignored")
+	slogger.With("user_id", 42).WithGroup("req").Warn("slow", "ms", 1500, slog.Group("db", "rows", 3))
+
+	// Then
+	AssertEquals(t, "WARN  AnyName: slow user_id=42 req.ms=1500 req.db.rows=3\n", output.String())
+}
+
+func TestShouldForwardRecordsToSlogHandler(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetLogLevel(logger.LevelAll)
+	log.SetSlogOutput(slog.NewTextHandler(&output, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	// When
+	log.Debug("ignored")
+	log.Errorw("failed", "attempt", 3)
+
+	// Then
+	line := output.String()
+	AssertEquals(t, 1, strings.Count(line, "\n"))
+	AssertEquals(t, true, strings.Contains(line, `level=ERROR msg=failed logger=AnyName attempt=3`))
+}
@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ecromaneli-golang/console/logger"
+)
+
+// tracedError mimics errors that record a stack trace and print it with "%+v".
+type tracedError struct{}
+
+func (tracedError) Error() string {
+	return "traced failure"
+}
+
+func (e tracedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		io.WriteString(s, "traced failure\nmain.origin\n\t/src/origin.go:7")
+		return
+	}
+	io.WriteString(s, e.Error())
+}
+
+func TestShouldAttachStackTraceFromLevel(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetStacktraceLevel(logger.LevelError)
+
+	// When
+	log.Warn("no stack")
+	warn := output.String()
+	output.Reset()
+	log.Error("with stack")
+
+	// Then
+	lines := strings.Split(output.String(), "\n")
+	AssertEquals(t, "WARN  AnyName: no stack\n", warn)
+	AssertEquals(t, "ERROR AnyName: with stack", lines[0])
+	AssertEquals(t, true, strings.HasPrefix(lines[1], "\t"))
+	AssertEquals(t, true, strings.HasSuffix(lines[1], "TestShouldAttachStackTraceFromLevel"))
+	AssertEquals(t, true, strings.HasPrefix(lines[2], "\t\t"))
+	AssertEquals(t, true, strings.Contains(lines[2], "stack_test.go:"))
+}
+
+func TestShouldIndentStackTraceThroughDefaultLogDispatcher(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetLogDispatcher(logger.DefaultLogDispatcher)
+	log.SetStacktraceLevel(logger.LevelError)
+
+	// When
+	log.Error("with stack")
+
+	// Then
+	lines := strings.Split(output.String(), "\n")
+	AssertEquals(t, "ERROR AnyName: with stack", lines[0])
+	AssertEquals(t, true, strings.HasPrefix(lines[1], "\t"))
+	AssertEquals(t, true, strings.HasSuffix(lines[1], "TestShouldIndentStackTraceThroughDefaultLogDispatcher"))
+	AssertEquals(t, true, strings.HasPrefix(lines[2], "\t\t"))
+	AssertEquals(t, false, strings.Contains(output.String(), "stack="))
+}
+
+func TestShouldIncludeStackTraceOfErrors(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetOutput(&output)
+	log.SetHandler(logger.NewJSONHandler(logger.DefaultJSONKeys))
+	log.SetStacktraceLevel(logger.LevelFatal)
+
+	// When
+	log.Error("ignored")
+	output.Reset()
+	log.Fatalw("failed", "err", fmt.Errorf("wrapped: %w", tracedError{}))
+
+	// Then
+	var entry map[string]any
+	if err := json.Unmarshal(output.Bytes(), &entry); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output.String(), err)
+	}
+	stack := entry["stack"].(string)
+	AssertEquals(t, true, strings.HasPrefix(stack, "traced failure\nmain.origin\n\t/src/origin.go:7\n"))
+	AssertEquals(t, true, strings.Contains(stack, "TestShouldIncludeStackTraceOfErrors"))
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+	"github.com/ecromaneli-golang/console/logger/syslog"
+)
+
+// readOctetCounted reads a message framed by octet counting.
+func readOctetCounted(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	length, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatal(err)
+	}
+	return string(msg)
+}
+
+func TestShouldSendRFC5424MessagesOverUDP(t *testing.T) {
+	// Given
+	listener, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	writer, err := syslog.Dial("udp", listener.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	log := logger.New("db.pool")
+	log.SetOutput(writer)
+	log.SetHandler(syslog.NewHandler(syslog.Options{Facility: syslog.Local0, Hostname: "host"}))
+
+	// When
+	log.Warnw("disk almost full", "used", 93, "path", `C:\logs]"`)
+
+	// Then
+	buf := make([]byte, 2048)
+	listener.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := listener.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.SplitN(string(buf[:n]), " ", 7)
+	_, timeErr := time.Parse(time.RFC3339Nano, parts[1])
+	AssertEquals(t, "<132>1", parts[0])
+	AssertEquals(t, nil, timeErr)
+	AssertEquals(t, "host db.pool "+strconv.Itoa(os.Getpid())+" -", strings.Join(parts[2:6], " "))
+	AssertEquals(t, `[fields@32473 used="93" path="C:\\logs\]\""] disk almost full`, parts[6])
+}
+
+func TestShouldUseLoggerNameAsMsgID(t *testing.T) {
+	// Given
+	var output SafeBuffer
+	handler := syslog.NewHandler(syslog.Options{Hostname: "host", AppName: "billing", NameAsMsgID: true, StructuredDataID: "meta@12345"})
+
+	log := logger.New("db pool")
+	log.SetOutput(&output)
+	log.SetHandler(handler)
+
+	// When
+	log.Error("failed")
+
+	// Then
+	parts := strings.SplitN(output.String(), " ", 7)
+	AssertEquals(t, "<11>1", parts[0])
+	AssertEquals(t, "host billing "+strconv.Itoa(os.Getpid())+" db_pool - failed", strings.Join(parts[2:], " "))
+}
+
+func TestShouldSendRFC3164MessagesOverTCP(t *testing.T) {
+	// Given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	writer, err := syslog.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	log := logger.New("api")
+	log.SetOutput(writer)
+	log.SetLogLevel(logger.LevelAll)
+	log.SetHandler(syslog.NewHandler(syslog.Options{Format: syslog.RFC3164, Facility: syslog.Daemon, Hostname: "host"}))
+
+	// When
+	log.Infow("request served", "status", 200)
+	log.Debug("multi\nline")
+
+	// Then
+	reader := bufio.NewReader(conn)
+	first, second := readOctetCounted(t, reader), readOctetCounted(t, reader)
+	stamp := len("Jan _2 15:04:05")
+	AssertEquals(t, "<30>", first[:4])
+	AssertEquals(t, " host api["+strconv.Itoa(os.Getpid())+"]: request served status=200", first[4+stamp:])
+	AssertEquals(t, " host api["+strconv.Itoa(os.Getpid())+"]: multi\nline", second[4+stamp:])
+}
+
+func TestShouldReconnectToSyslogServer(t *testing.T) {
+	// Given
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	writer, err := syslog.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+
+	first, _ := listener.Accept()
+	first.Close()
+
+	// When
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			accepted <- conn
+		}
+	}()
+
+	var conn net.Conn
+	deadline := time.Now().Add(5 * time.Second)
+	for conn == nil && time.Now().Before(deadline) {
+		writer.Write([]byte("any message"))
+		select {
+		case conn = <-accepted:
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	if conn == nil {
+		t.Fatal("the writer did not reconnect")
+	}
+	defer conn.Close()
+	msg := readOctetCounted(t, bufio.NewReader(conn))
+
+	// Then
+	AssertEquals(t, "any message", msg)
+}
+
+func TestShouldMapLevelsToSeverities(t *testing.T) {
+	for lv, expected := range map[logger.Level]syslog.Severity{
+		logger.LevelFatal: syslog.Critical,
+		logger.LevelError: syslog.Error,
+		logger.LevelWarn:  syslog.Warning,
+		logger.Level(17):  syslog.Notice,
+		logger.LevelInfo:  syslog.Informational,
+		logger.LevelDebug: syslog.Debug,
+		logger.LevelTrace: syslog.Debug,
+	} {
+		AssertEquals(t, expected, syslog.SeverityOf(lv))
+	}
+}
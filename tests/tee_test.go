@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecromaneli-golang/console/logger"
+	"github.com/ecromaneli-golang/console/logger/async"
+)
+
+// messageWriter records every write as a separate message, as datagram sockets do.
+type messageWriter struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (w *messageWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.messages = append(w.messages, string(p))
+	return len(p), nil
+}
+
+func (w *messageWriter) Messages() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string(nil), w.messages...)
+}
+
+func TestShouldWriteToBranchesByLevel(t *testing.T) {
+	// Given
+	var errors, infos bytes.Buffer
+	var debugs SafeBuffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetLogLevel(logger.LevelAll)
+	log.SetHandler(logger.NewTeeHandler(
+		logger.Branch{Level: logger.LevelError, Handler: logger.TextHandler{}, Writer: &errors},
+		logger.Branch{Level: logger.LevelInfo, Handler: logger.NewJSONHandler(logger.JSONKeys{Level: "level", Message: "msg"}), Writer: &infos},
+		logger.Branch{Level: logger.LevelDebug, Handler: logger.TextHandler{}, Writer: &debugs, Async: true},
+	))
+
+	// When
+	log.Trace("ignored")
+	log.Debug("debug")
+	log.Infow("info", "k", "v")
+	log.Error("error")
+	log.Flush()
+
+	// Then
+	AssertEquals(t, "ERROR AnyName: error\n", errors.String())
+	AssertEquals(t, `{"level":"INFO","msg":"info","k":"v"}`+"\n"+`{"level":"ERROR","msg":"error"}`+"\n", infos.String())
+	AssertEquals(t, "DEBUG AnyName: debug\nINFO  AnyName: info k=v\nERROR AnyName: error\n", debugs.String())
+	AssertEquals(t, nil, log.Close())
+}
+
+func TestShouldEnableLowestBranchLevel(t *testing.T) {
+	// Given
+	handled := 0
+	counting := logger.HandlerFunc(func(_ io.Writer, _ *logger.Record) {
+		handled++
+	})
+
+	log := logger.New("AnyName")
+	log.SetLogLevel(logger.LevelAll)
+	log.SetHandler(logger.NewTeeHandler(
+		logger.Branch{Level: logger.LevelError, Handler: counting},
+		logger.Branch{Level: logger.LevelInfo, Handler: counting},
+	))
+
+	// When
+	info, debug := log.IsInfoEnabled(), log.IsDebugEnabled()
+	log.SetLogLevel(logger.LevelWarn)
+	restricted := log.IsInfoEnabled()
+	log.Error("counted twice")
+
+	// Then
+	AssertEquals(t, true, info)
+	AssertEquals(t, false, debug)
+	AssertEquals(t, false, restricted)
+	AssertEquals(t, 2, handled)
+}
+
+func TestShouldWriteBranchToLoggerOutput(t *testing.T) {
+	// Given
+	var output bytes.Buffer
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetOutput(&output)
+	log.SetHandler(logger.NewTeeHandler(logger.Branch{Level: logger.LevelWarn, Handler: logger.TextHandler{}}))
+
+	// When
+	log.Info("ignored")
+	log.Warn("any message")
+
+	// Then
+	AssertEquals(t, "WARN  AnyName: any message\n", output.String())
+}
+
+func TestShouldConfigureAsyncBranches(t *testing.T) {
+	// Given
+	separate, batched := &messageWriter{}, &messageWriter{}
+
+	log := logger.New("AnyName")
+	log.SetDateFormat("")
+	log.SetHandler(logger.NewTeeHandler(
+		logger.Branch{Level: logger.LevelInfo, Handler: logger.TextHandler{}, Writer: separate, Async: true},
+		logger.Branch{Level: logger.LevelInfo, Handler: logger.TextHandler{}, Writer: batched, Async: true,
+			AsyncOptions: async.Options{MaxBatchBytes: 1024, FlushInterval: time.Hour}},
+	))
+
+	// When
+	log.Info("first")
+	log.Info("second")
+	log.Info("third")
+	log.Flush()
+
+	// Then
+	AssertEquals(t, `["INFO  AnyName: first\n" "INFO  AnyName: second\n" "INFO  AnyName: third\n"]`, fmt.Sprintf("%q", separate.Messages()))
+	AssertEquals(t, `["INFO  AnyName: first\nINFO  AnyName: second\nINFO  AnyName: third\n"]`, fmt.Sprintf("%q", batched.Messages()))
+	AssertEquals(t, nil, log.Close())
+}
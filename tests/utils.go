@@ -1,8 +1,10 @@
 package tests
 
 import (
+	"bytes"
 	"fmt"
 	"io"
+	"sync"
 	"testing"
 
 	"github.com/ecromaneli-golang/console/logger"
@@ -62,3 +64,21 @@ func AssertEquals(t *testing.T, expected any, current any) {
 		t.Errorf("\n\nExpected: %v\nCurrent: %v\n\n", expected, current)
 	}
 }
+
+// SafeBuffer is a bytes.Buffer that is safe for concurrent use.
+type SafeBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *SafeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *SafeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.String()
+}